@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+type addressMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewAddressMysqlRepository(conn *sql.DB) domain.AddressRepository {
+	return &addressMysqlRepository{Conn: conn}
+}
+
+func (r *addressMysqlRepository) Add(ctx context.Context, login string, a *domain.Address) error {
+	query := `INSERT INTO address (uuid, login, label, street, number, city, state, zipcode, country, is_default) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	a.UUID = uuid.NewString()
+	a.Login = login
+
+	exec, err := stmt.ExecContext(ctx, a.UUID, a.Login, a.Label, a.Street, a.Number, a.City, a.State, a.ZipCode, a.Country, a.Default)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store address with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *addressMysqlRepository) List(ctx context.Context, login string) ([]domain.Address, error) {
+	query := `SELECT id, uuid, login, label, street, number, city, state, zipcode, country, is_default FROM address WHERE login = ? ORDER BY id;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var res []domain.Address
+
+	for rows.Next() {
+		var a domain.Address
+
+		if err := rows.Scan(&a.ID, &a.UUID, &a.Login, &a.Label, &a.Street, &a.Number, &a.City, &a.State, &a.ZipCode, &a.Country, &a.Default); err != nil {
+			return nil, err
+		}
+
+		res = append(res, a)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *addressMysqlRepository) Update(ctx context.Context, login string, a *domain.Address) error {
+	query := `UPDATE address SET label=?, street=?, number=?, city=?, state=?, zipcode=?, country=? WHERE uuid=? AND login=?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, a.Label, a.Street, a.Number, a.City, a.State, a.ZipCode, a.Country, a.UUID, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to update address with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *addressMysqlRepository) Delete(ctx context.Context, login string, uuid string) error {
+	query := `DELETE FROM address WHERE uuid = ? AND login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, uuid, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to remove address with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// SetDefault flags the address as default and clears every other address of
+// the login in a single statement, so there is never more than one default.
+func (r *addressMysqlRepository) SetDefault(ctx context.Context, login string, uuid string) error {
+	query := `UPDATE address SET is_default = (uuid = ?) WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, uuid, login); err != nil {
+		return err
+	}
+
+	return nil
+}
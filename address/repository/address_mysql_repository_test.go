@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO address (uuid, login, label, street, number, city, state, zipcode, country, is_default) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "login", "home", "street", "number", "city", "state", "zipcode", "country", true).WillReturnError(errors.New("error message"))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	address := &domain.Address{Label: "home", Street: "street", Number: "number", City: "city", State: "state", ZipCode: "zipcode", Country: "country", Default: true}
+
+	err = addressMysqlRepository.Add(context.Background(), "login", address)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO address (uuid, login, label, street, number, city, state, zipcode, country, is_default) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "login", "home", "street", "number", "city", "state", "zipcode", "country", true).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	address := &domain.Address{Label: "home", Street: "street", Number: "number", City: "city", State: "state", ZipCode: "zipcode", Country: "country", Default: true}
+
+	err = addressMysqlRepository.Add(context.Background(), "login", address)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, address.UUID)
+	assert.Equal(t, "login", address.Login)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT id, uuid, login, label, street, number, city, state, zipcode, country, is_default FROM address WHERE login = ? ORDER BY id;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnError(errors.New("error message"))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	_, err = addressMysqlRepository.List(context.Background(), "login")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "label", "street", "number", "city", "state", "zipcode", "country", "is_default"}).
+		AddRow(1, "uuid 1", "login", "home", "street", "number", "city", "state", "zipcode", "country", true).
+		AddRow(2, "uuid 2", "login", "work", "street", "number", "city", "state", "zipcode", "country", false)
+
+	query := regexp.QuoteMeta("SELECT id, uuid, login, label, street, number, city, state, zipcode, country, is_default FROM address WHERE login = ? ORDER BY id;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	addresses, err := addressMysqlRepository.List(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 2)
+	assert.Equal(t, "uuid 1", addresses[0].UUID)
+	assert.Equal(t, "home", addresses[0].Label)
+	assert.True(t, addresses[0].Default)
+	assert.Equal(t, "uuid 2", addresses[1].UUID)
+	assert.False(t, addresses[1].Default)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE address SET label=?, street=?, number=?, city=?, state=?, zipcode=?, country=? WHERE uuid=? AND login=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("home", "street", "number", "city", "state", "zipcode", "country", "uuid", "login").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.Update(context.Background(), "login", &domain.Address{UUID: "uuid", Label: "home", Street: "street", Number: "number", City: "city", State: "state", ZipCode: "zipcode", Country: "country"})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE address SET label=?, street=?, number=?, city=?, state=?, zipcode=?, country=? WHERE uuid=? AND login=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("home", "street", "number", "city", "state", "zipcode", "country", "uuid", "login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.Update(context.Background(), "login", &domain.Address{UUID: "uuid", Label: "home", Street: "street", Number: "number", City: "city", State: "state", ZipCode: "zipcode", Country: "country"})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM address WHERE uuid = ? AND login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid", "login").WillReturnError(errors.New("error message"))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.Delete(context.Background(), "login", "uuid")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM address WHERE uuid = ? AND login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid", "login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.Delete(context.Background(), "login", "uuid")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetDefaultError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE address SET is_default = (uuid = ?) WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid", "login").WillReturnError(errors.New("error message"))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.SetDefault(context.Background(), "login", "uuid")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE address SET is_default = (uuid = ?) WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid", "login").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	addressMysqlRepository := NewAddressMysqlRepository(db)
+
+	err = addressMysqlRepository.SetDefault(context.Background(), "login", "uuid")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
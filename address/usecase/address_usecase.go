@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type addressUseCase struct {
+	addressRepo domain.AddressRepository
+}
+
+func NewAddressUseCase(ar domain.AddressRepository) domain.AddressUseCase {
+	return &addressUseCase{addressRepo: ar}
+}
+
+func findAddress(addresses []domain.Address, uuid string) *domain.Address {
+	for i := range addresses {
+		if addresses[i].UUID == uuid {
+			return &addresses[i]
+		}
+	}
+
+	return nil
+}
+
+// Add stores a new address, the first address of a login is always the default
+// one and asking for a later one to be default moves the flag to it.
+func (au *addressUseCase) Add(ctx context.Context, login string, a *domain.Address) error {
+	addresses, err := au.addressRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	makeDefault := a.Default
+
+	a.Default = len(addresses) == 0
+
+	if err := au.addressRepo.Add(ctx, login, a); err != nil {
+		return err
+	}
+
+	if makeDefault && !a.Default {
+		if err := au.addressRepo.SetDefault(ctx, login, a.UUID); err != nil {
+			return err
+		}
+
+		a.Default = true
+	}
+
+	return nil
+}
+
+func (au *addressUseCase) List(ctx context.Context, login string) ([]domain.Address, error) {
+	return au.addressRepo.List(ctx, login)
+}
+
+// Update changes the address fields, the default flag only moves through SetDefault.
+func (au *addressUseCase) Update(ctx context.Context, login string, a *domain.Address) error {
+	return au.addressRepo.Update(ctx, login, a)
+}
+
+// Delete removes the address, when it was the default one the oldest remaining
+// address becomes the default.
+func (au *addressUseCase) Delete(ctx context.Context, login string, uuid string) error {
+	addresses, err := au.addressRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	address := findAddress(addresses, uuid)
+
+	if address == nil {
+		return fmt.Errorf("address %s not found for login %s", uuid, login)
+	}
+
+	if err := au.addressRepo.Delete(ctx, login, uuid); err != nil {
+		return err
+	}
+
+	if !address.Default {
+		return nil
+	}
+
+	for _, remaining := range addresses {
+		if remaining.UUID != uuid {
+			return au.addressRepo.SetDefault(ctx, login, remaining.UUID)
+		}
+	}
+
+	return nil
+}
+
+func (au *addressUseCase) SetDefault(ctx context.Context, login string, uuid string) error {
+	addresses, err := au.addressRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	address := findAddress(addresses, uuid)
+
+	if address == nil {
+		return fmt.Errorf("address %s not found for login %s", uuid, login)
+	}
+
+	if address.Default {
+		return nil
+	}
+
+	return au.addressRepo.SetDefault(ctx, login, uuid)
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func storedAddresses() []domain.Address {
+	return []domain.Address{
+		{ID: 1, UUID: "home uuid", Login: "login", Label: "home", Default: true},
+		{ID: 2, UUID: "work uuid", Login: "login", Label: "work"},
+	}
+}
+
+func TestAddListError(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(nil, errors.New("error message"))
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Add(context.Background(), "login", &domain.Address{Label: "home"})
+
+	assert.Error(t, err)
+	mockAddressRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddFirstAddressIsDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(nil, nil)
+	mockAddressRepo.On("Add", mock.Anything, "login", mock.MatchedBy(func(a *domain.Address) bool { return a.Default })).Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	address := &domain.Address{Label: "home"}
+
+	err := addressUseCase.Add(context.Background(), "login", address)
+
+	assert.NoError(t, err)
+	assert.True(t, address.Default)
+	mockAddressRepo.AssertExpectations(t)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddKeepsCurrentDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("Add", mock.Anything, "login", mock.MatchedBy(func(a *domain.Address) bool { return !a.Default })).Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	address := &domain.Address{Label: "parents"}
+
+	err := addressUseCase.Add(context.Background(), "login", address)
+
+	assert.NoError(t, err)
+	assert.False(t, address.Default)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddAsNewDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("Add", mock.Anything, "login", mock.MatchedBy(func(a *domain.Address) bool { return !a.Default })).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.Address).UUID = "parents uuid"
+	}).Return(nil)
+	mockAddressRepo.On("SetDefault", mock.Anything, "login", "parents uuid").Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	address := &domain.Address{Label: "parents", Default: true}
+
+	err := addressUseCase.Add(context.Background(), "login", address)
+
+	assert.NoError(t, err)
+	assert.True(t, address.Default)
+	mockAddressRepo.AssertExpectations(t)
+}
+
+func TestSetDefaultNotFound(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.SetDefault(context.Background(), "login", "other uuid")
+
+	assert.Error(t, err)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetDefaultAlreadyDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.SetDefault(context.Background(), "login", "home uuid")
+
+	assert.NoError(t, err)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("SetDefault", mock.Anything, "login", "work uuid").Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.SetDefault(context.Background(), "login", "work uuid")
+
+	assert.NoError(t, err)
+	mockAddressRepo.AssertExpectations(t)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Delete(context.Background(), "login", "other uuid")
+
+	assert.Error(t, err)
+	mockAddressRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteNotDefault(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("Delete", mock.Anything, "login", "work uuid").Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Delete(context.Background(), "login", "work uuid")
+
+	assert.NoError(t, err)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteDefaultPromotesRemaining(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("Delete", mock.Anything, "login", "home uuid").Return(nil)
+	mockAddressRepo.On("SetDefault", mock.Anything, "login", "work uuid").Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Delete(context.Background(), "login", "home uuid")
+
+	assert.NoError(t, err)
+	mockAddressRepo.AssertExpectations(t)
+}
+
+func TestDeleteLastAddress(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses()[:1], nil)
+	mockAddressRepo.On("Delete", mock.Anything, "login", "home uuid").Return(nil)
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Delete(context.Background(), "login", "home uuid")
+
+	assert.NoError(t, err)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteError(t *testing.T) {
+	mockAddressRepo := new(mocks.MockAddressRepository)
+
+	mockAddressRepo.On("List", mock.Anything, "login").Return(storedAddresses(), nil)
+	mockAddressRepo.On("Delete", mock.Anything, "login", "home uuid").Return(errors.New("error message"))
+
+	addressUseCase := NewAddressUseCase(mockAddressRepo)
+
+	err := addressUseCase.Delete(context.Background(), "login", "home uuid")
+
+	assert.Error(t, err)
+	mockAddressRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+}
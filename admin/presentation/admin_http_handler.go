@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type adminHandler struct {
+	AdminUseCase domain.AdminUseCase
+}
+
+func NewAdminHandler(e *echo.Echo, auc domain.AdminUseCase, auth echo.MiddlewareFunc) *adminHandler {
+	handler := &adminHandler{
+		AdminUseCase: auc,
+	}
+
+	e.POST("/admin/invites", handler.CreateInvite, auth)
+
+	return handler
+}
+
+func (ah *adminHandler) CreateInvite(c echo.Context) error {
+	var inviteReq struct {
+		Email string `json:"email"`
+	}
+
+	if err := c.Bind(&inviteReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if inviteReq.Email == "" {
+		return c.JSON(http.StatusBadRequest, "email is required")
+	}
+
+	invite, err := ah.AdminUseCase.CreateInvite(c.Request().Context(), inviteReq.Email)
+
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return c.JSON(http.StatusForbidden, "request not allowed")
+		}
+		log.Printf("Error trying to create an invite: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the invite")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"invite": invite.Value})
+}
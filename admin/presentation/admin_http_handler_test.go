@@ -0,0 +1,83 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	_adminUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/admin/usecase"
+	_authPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/presentation"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// serveCreateInvite sends POST /admin/invites with token through the real
+// auth middleware and admin use case, only the token decoding and the
+// repository are mocked.
+func serveCreateInvite(token string, role string, body string, mockCodeRepo *mocks.MockCodeRepository) *httptest.ResponseRecorder {
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyToken", mock.Anything, domain.Token(token)).Return("valid login", role, nil)
+
+	e := echo.New()
+
+	NewAdminHandler(e, _adminUsecase.NewAdminUseCase(mockCodeRepo), _authPresentation.NewAuthMiddleware(mockAuthUsecase))
+
+	req := httptest.NewRequest(echo.POST, "/admin/invites", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Authorization", token)
+
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestCreateInviteAsAdmin(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	mockCodeRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	rec := serveCreateInvite("admin token", domain.RoleAdmin, `{"email": "invited@email.com"}`, mockCodeRepo)
+
+	var resp map[string]string
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp["invite"])
+}
+
+func TestCreateInviteAsCustomer(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	rec := serveCreateInvite("customer token", domain.RoleCustomer, `{"email": "invited@email.com"}`, mockCodeRepo)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockCodeRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateInviteWithoutEmail(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	rec := serveCreateInvite("admin token", domain.RoleAdmin, `{}`, mockCodeRepo)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockCodeRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateInviteError(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	mockCodeRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	rec := serveCreateInvite("admin token", domain.RoleAdmin, `{"email": "invited@email.com"}`, mockCodeRepo)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
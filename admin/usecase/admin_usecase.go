@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type adminUseCase struct {
+	codeRepo domain.CodeRepository
+}
+
+func NewAdminUseCase(cr domain.CodeRepository) domain.AdminUseCase {
+	return &adminUseCase{
+		codeRepo: cr,
+	}
+}
+
+// inviteToken is drawn from crypto/rand, an invite grants an account so it
+// must not be guessable the way short verification codes are.
+func inviteToken() (string, error) {
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (adu *adminUseCase) CreateInvite(ctx context.Context, email string) (*domain.Code, error) {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	value, err := inviteToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &domain.Code{Value: value, Identifier: domain.InviteIdentifierPrefix + email, CreatedAt: time.Now()}
+
+	if err := adu.codeRepo.Store(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
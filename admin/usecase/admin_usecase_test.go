@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var adminCtx = domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "admin login", Role: domain.RoleAdmin})
+
+func TestCreateInviteNotAdmin(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	ctx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer login", Role: domain.RoleCustomer})
+
+	adminUseCase := NewAdminUseCase(mockCodeRepo)
+
+	_, err := adminUseCase.CreateInvite(ctx, "invited@email.com")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockCodeRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateInviteWithoutToken(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	adminUseCase := NewAdminUseCase(mockCodeRepo)
+
+	_, err := adminUseCase.CreateInvite(context.Background(), "invited@email.com")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockCodeRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateInviteStoreError(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	mockCodeRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	adminUseCase := NewAdminUseCase(mockCodeRepo)
+
+	_, err := adminUseCase.CreateInvite(adminCtx, "invited@email.com")
+
+	assert.Error(t, err)
+}
+
+func TestCreateInvite(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	mockCodeRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	adminUseCase := NewAdminUseCase(mockCodeRepo)
+
+	invite, err := adminUseCase.CreateInvite(adminCtx, "invited@email.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InviteIdentifierPrefix+"invited@email.com", invite.Identifier)
+	assert.Len(t, invite.Value, 43)
+	assert.True(t, invite.ExpiresAt.IsZero())
+	mockCodeRepo.AssertCalled(t, "Store", mock.Anything, invite)
+}
+
+func TestCreateInviteIsNotRepeated(t *testing.T) {
+	mockCodeRepo := new(mocks.MockCodeRepository)
+
+	mockCodeRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	adminUseCase := NewAdminUseCase(mockCodeRepo)
+
+	first, err := adminUseCase.CreateInvite(adminCtx, "invited@email.com")
+	assert.NoError(t, err)
+
+	second, err := adminUseCase.CreateInvite(adminCtx, "invited@email.com")
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.Value, second.Value)
+}
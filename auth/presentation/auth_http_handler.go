@@ -1,6 +1,7 @@
 package presentation
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -21,42 +22,129 @@ func NewAuthHandler(e *echo.Echo, auc domain.AuthUseCase, av domain.AuthValidato
 		UserValidator: uv,
 	}
 	e.POST("/login", handler.Login)
+	e.POST("/login/twofactor", handler.LoginTwoFactor)
 	e.POST("/signup", handler.SignUp)
 	e.POST("/forgotpass/code", handler.ForgotPassCode)
 	e.POST("/forgotpass/reset", handler.ForgotPassReset)
+	e.POST("/logout", handler.Logout)
+	e.POST("/refresh", handler.RefreshToken)
+	e.POST("/verifyemail", handler.VerifyEmail)
 
 	return handler
 }
 
+func writeAuthResult(c echo.Context, result *domain.AuthResult) error {
+	if result.TwoFactorToken != "" {
+		return c.JSON(http.StatusOK, map[string]string{"twoFactorToken": string(result.TwoFactorToken)})
+	}
+
+	if result.Transport == domain.TokenTransportCookie {
+		c.SetCookie(&http.Cookie{
+			Name:     domain.TokenCookieName,
+			Value:    string(result.Token),
+			MaxAge:   int(result.MaxAge),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		if result.RefreshToken != "" {
+			c.SetCookie(&http.Cookie{
+				Name:     domain.RefreshTokenCookieName,
+				Value:    string(result.RefreshToken),
+				MaxAge:   int(result.RefreshMaxAge),
+				Path:     "/refresh",
+				HttpOnly: true,
+				Secure:   true,
+				SameSite: http.SameSiteStrictMode,
+			})
+		}
+		return c.String(http.StatusOK, "")
+	}
+
+	body := map[string]string{"token": string(result.Token)}
+
+	if result.RefreshToken != "" {
+		body["refreshToken"] = string(result.RefreshToken)
+	}
+
+	return c.JSON(http.StatusOK, body)
+}
+
 func (ah *authHandler) Login(c echo.Context) error {
-	var auth domain.Auth
+	var loginReq struct {
+		domain.Auth
+		Client string `json:"client"`
+	}
 
-	if err := c.Bind(&auth); err != nil {
+	if err := c.Bind(&loginReq); err != nil {
 		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
 	}
 
 	ctx := c.Request().Context()
 
+	auth := domain.Auth{
+		Login:    loginReq.Login,
+		Password: loginReq.Password,
+	}
+
 	isValid, message := ah.AuthValidator.Validate(ctx, &auth)
 
 	if !isValid {
 		return c.JSON(http.StatusBadRequest, message)
 	}
 
-	token, err := ah.AuthUseCase.Login(ctx, &auth)
+	result, err := ah.AuthUseCase.Login(ctx, &auth, domain.LoginInput{Client: loginReq.Client})
 
 	if err != nil {
 		log.Printf("Error trying to generate token for Login: %s", err.Error())
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			return c.JSON(http.StatusUnauthorized, "invalid login or password")
+		}
+		if errors.Is(err, domain.ErrUnknownClient) {
+			return c.JSON(http.StatusBadRequest, "client is not allowed to login")
+		}
+		return c.JSON(http.StatusInternalServerError, "failed to login")
+	}
+
+	return writeAuthResult(c, result)
+}
+
+func (ah *authHandler) LoginTwoFactor(c echo.Context) error {
+	var loginTwoFactorReq struct {
+		TwoFactorToken string `json:"twoFactorToken"`
+		Code           string `json:"code"`
+	}
+
+	if err := c.Bind(&loginTwoFactorReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if loginTwoFactorReq.TwoFactorToken == "" || loginTwoFactorReq.Code == "" {
+		return c.JSON(http.StatusBadRequest, "two factor token and code are required")
+	}
+
+	result, err := ah.AuthUseCase.LoginTwoFactor(c.Request().Context(), domain.Token(loginTwoFactorReq.TwoFactorToken), loginTwoFactorReq.Code)
+
+	if err != nil {
+		log.Printf("Error trying to complete two factor login: %s", err.Error())
+		if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrInvalidTwoFactor) {
+			return c.JSON(http.StatusUnauthorized, "invalid two factor token or code")
+		}
 		return c.JSON(http.StatusInternalServerError, "failed to login")
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"token": string(token)})
+	return writeAuthResult(c, result)
 }
 
 func (ah *authHandler) SignUp(c echo.Context) error {
 	var authWithUser struct {
 		domain.Auth
 		domain.User
+		Client       string `json:"client"`
+		Invite       string `json:"invite"`
+		Website      string `json:"website"`
+		TermsVersion string `json:"termsVersion"`
 	}
 
 	if err := c.Bind(&authWithUser); err != nil {
@@ -90,19 +178,35 @@ func (ah *authHandler) SignUp(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, message)
 	}
 
-	token, err := ah.AuthUseCase.SignUp(ctx, &authWithUser.Auth, &authWithUser.User)
+	result, err := ah.AuthUseCase.SignUp(ctx, &authWithUser.Auth, &authWithUser.User, domain.SignUpInput{Client: authWithUser.Client, InviteToken: authWithUser.Invite, Honeypot: authWithUser.Website, TermsVersion: authWithUser.TermsVersion})
 
 	if err != nil {
 		log.Printf("Error trying to sign up: %s", err.Error())
+		if errors.Is(err, domain.ErrLoginTaken) {
+			return c.JSON(http.StatusConflict, "login already taken")
+		}
+		if errors.Is(err, domain.ErrEmailTaken) {
+			return c.JSON(http.StatusConflict, "email already taken")
+		}
+		if errors.Is(err, domain.ErrPhoneTaken) {
+			return c.JSON(http.StatusConflict, "phone number already taken")
+		}
+		if errors.Is(err, domain.ErrWeakPassword) {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, domain.ErrUnknownClient) {
+			return c.JSON(http.StatusBadRequest, "client is not allowed to sign up")
+		}
 		return c.JSON(http.StatusInternalServerError, "failed to sign up")
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"token": string(token)})
+	return writeAuthResult(c, result)
 }
 
 func (ah *authHandler) ForgotPassCode(c echo.Context) error {
 	var forgotPassReq struct {
-		Login string `json:"login"`
+		Login   string `json:"login"`
+		Channel string `json:"channel"`
 	}
 
 	if err := c.Bind(&forgotPassReq); err != nil {
@@ -117,7 +221,13 @@ func (ah *authHandler) ForgotPassCode(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, message)
 	}
 
-	if err := ah.AuthUseCase.ForgotPassCode(ctx, forgotPassReq.Login); err != nil {
+	err := ah.AuthUseCase.ForgotPassCode(ctx, forgotPassReq.Login, forgotPassReq.Channel)
+
+	if errors.Is(err, domain.ErrTooManyRequests) {
+		return c.JSON(http.StatusTooManyRequests, "wait before requesting another code")
+	}
+
+	if err != nil {
 		log.Printf("Error trying to send forgot password code: %s", err.Error())
 		return c.JSON(http.StatusInternalServerError, "failed to send forgot password code")
 	}
@@ -130,6 +240,8 @@ func (ah *authHandler) ForgotPassReset(c echo.Context) error {
 		Login   string `json:"login"`
 		Code    string `json:"code"`
 		NewPass string `json:"newPassword"`
+		Channel string `json:"channel"`
+		Client  string `json:"client"`
 	}
 
 	if err := c.Bind(&forgotPassResetReq); err != nil {
@@ -150,14 +262,133 @@ func (ah *authHandler) ForgotPassReset(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, message)
 	}
 
-	code := domain.Code{Identifier: forgotPassResetReq.Login, Value: forgotPassResetReq.Code}
+	code := domain.Code{Identifier: forgotPassResetReq.Login, Value: forgotPassResetReq.Code, Channel: forgotPassResetReq.Channel}
+
+	result, err := ah.AuthUseCase.ForgotPassReset(ctx, &code, forgotPassResetReq.NewPass, forgotPassResetReq.Client)
+
+	if errors.Is(err, domain.ErrCodeExpired) {
+		return c.JSON(http.StatusBadRequest, "code is expired, request a new one")
+	}
 
-	token, err := ah.AuthUseCase.ForgotPassReset(ctx, &code, forgotPassResetReq.NewPass)
+	if errors.Is(err, domain.ErrWeakPassword) {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrUnknownClient) {
+		return c.JSON(http.StatusBadRequest, "client is not allowed to login")
+	}
 
 	if err != nil {
 		log.Printf("Error trying to reset user's password: %s", err.Error())
 		return c.JSON(http.StatusInternalServerError, "failed to reset the password")
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"token": string(token)})
+	return writeAuthResult(c, result)
+}
+
+func (ah *authHandler) Logout(c echo.Context) error {
+	token := c.Request().Header.Get("Authorization")
+
+	cookie, err := c.Cookie(domain.TokenCookieName)
+
+	if token == "" && err == nil {
+		token = cookie.Value
+	}
+
+	if token == "" {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	var logoutReq struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	if err := c.Bind(&logoutReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if logoutReq.RefreshToken == "" {
+		if refreshCookie, err := c.Cookie(domain.RefreshTokenCookieName); err == nil {
+			logoutReq.RefreshToken = refreshCookie.Value
+		}
+	}
+
+	if err := ah.AuthUseCase.Logout(c.Request().Context(), domain.Token(token), domain.Token(logoutReq.RefreshToken)); err != nil {
+		log.Printf("Error trying to logout: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to logout")
+	}
+
+	if cookie != nil {
+		c.SetCookie(&http.Cookie{
+			Name:     domain.TokenCookieName,
+			Value:    "",
+			MaxAge:   -1,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		c.SetCookie(&http.Cookie{
+			Name:     domain.RefreshTokenCookieName,
+			Value:    "",
+			MaxAge:   -1,
+			Path:     "/refresh",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+
+	return c.String(http.StatusOK, "")
+}
+
+func (ah *authHandler) RefreshToken(c echo.Context) error {
+	var refreshReq struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	if err := c.Bind(&refreshReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if refreshReq.RefreshToken == "" {
+		if cookie, err := c.Cookie(domain.RefreshTokenCookieName); err == nil {
+			refreshReq.RefreshToken = cookie.Value
+		}
+	}
+
+	if refreshReq.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, "refresh token is required")
+	}
+
+	result, err := ah.AuthUseCase.RefreshToken(c.Request().Context(), domain.Token(refreshReq.RefreshToken))
+
+	if err != nil {
+		log.Printf("Error trying to refresh token: %s", err.Error())
+		return c.JSON(http.StatusUnauthorized, "failed to refresh token")
+	}
+
+	return writeAuthResult(c, result)
+}
+
+func (ah *authHandler) VerifyEmail(c echo.Context) error {
+	var verifyEmailReq struct {
+		Login string `json:"login"`
+		Code  string `json:"code"`
+	}
+
+	if err := c.Bind(&verifyEmailReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if verifyEmailReq.Login == "" || verifyEmailReq.Code == "" {
+		return c.JSON(http.StatusBadRequest, "login and code are required")
+	}
+
+	if err := ah.AuthUseCase.VerifyEmail(c.Request().Context(), verifyEmailReq.Login, verifyEmailReq.Code); err != nil {
+		log.Printf("Error trying to verify email: %s", err.Error())
+		return c.JSON(http.StatusBadRequest, "failed to verify email")
+	}
+
+	return c.String(http.StatusOK, "")
 }
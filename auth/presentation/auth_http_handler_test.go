@@ -2,6 +2,7 @@ package presentation
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -76,7 +77,7 @@ func TestLoginErrorGeneratingToken(t *testing.T) {
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	mockAuthUsecase.On("Login", mock.Anything, &mockAuth).Return("", errors.New("error message"))
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{}).Return(nil, errors.New("error message"))
 	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
@@ -87,6 +88,63 @@ func TestLoginErrorGeneratingToken(t *testing.T) {
 	assert.NotEqual(t, "", rec.Body.String())
 }
 
+func TestLoginInvalidCredentials(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/login",
+		strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{}).Return(nil, fmt.Errorf("wrong password: %w", domain.ErrInvalidCredentials))
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.Login(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEqual(t, "", rec.Body.String())
+}
+
+func TestLoginUnknownClient(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/login",
+		strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\",\"client\":\"desktop\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{Client: "desktop"}).Return(nil, fmt.Errorf("%w: client desktop can not login", domain.ErrUnknownClient))
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.Login(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestLoginSuccess(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
@@ -105,7 +163,67 @@ func TestLoginSuccess(t *testing.T) {
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	mockAuthUsecase.On("Login", mock.Anything, &mockAuth).Return("valid token", nil)
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{}).Return("valid token", "bearer", 0, nil)
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	err = handler.Login(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
+}
+
+func TestLoginForwardsClient(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST,
+		"/login", strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\",\"client\":\"web\"}"),
+	)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{Client: "web"}).Return("valid token", "bearer", 0, nil)
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	err = handler.Login(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
+}
+
+func TestLoginSuccessCookie(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST,
+		"/login", strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\"}"),
+	)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	mockAuthUsecase.On("Login", mock.Anything, &mockAuth, domain.LoginInput{}).Return("valid token", "cookie", 600, nil)
 	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
@@ -113,6 +231,104 @@ func TestLoginSuccess(t *testing.T) {
 	err = handler.Login(c)
 	require.NoError(t, err)
 
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", rec.Body.String())
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, domain.TokenCookieName, cookies[0].Name)
+	assert.Equal(t, "valid token", cookies[0].Value)
+	assert.Equal(t, 600, cookies[0].MaxAge)
+	assert.True(t, cookies[0].HttpOnly)
+}
+
+func TestWriteAuthResultRefreshCookie(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/login", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	result := &domain.AuthResult{TokenPair: domain.TokenPair{Token: "valid token", RefreshToken: "refresh token"}, Transport: domain.TokenTransportCookie, MaxAge: 600, RefreshMaxAge: 2592000}
+
+	err = writeAuthResult(c, result)
+	require.NoError(t, err)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 2)
+	assert.Equal(t, domain.RefreshTokenCookieName, cookies[1].Name)
+	assert.Equal(t, "refresh token", cookies[1].Value)
+	assert.Equal(t, "/refresh", cookies[1].Path)
+	assert.Equal(t, 2592000, cookies[1].MaxAge)
+}
+
+func TestLoginTwoFactorMissingCode(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/login/twofactor",
+		strings.NewReader("{\"twoFactorToken\":\"pending token\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.LoginTwoFactor(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockAuthUsecase.AssertNotCalled(t, "LoginTwoFactor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactorInvalidCode(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/login/twofactor",
+		strings.NewReader("{\"twoFactorToken\":\"pending token\",\"code\":\"123456\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("LoginTwoFactor", mock.Anything, domain.Token("pending token"), "123456").Return(nil, fmt.Errorf("%w for login valid login", domain.ErrInvalidTwoFactor))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.LoginTwoFactor(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestLoginTwoFactorSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/login/twofactor",
+		strings.NewReader("{\"twoFactorToken\":\"pending token\",\"code\":\"123456\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("LoginTwoFactor", mock.Anything, domain.Token("pending token"), "123456").Return("valid token", "bearer", 0, nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	err = handler.LoginTwoFactor(c)
+	require.NoError(t, err)
+
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
 }
@@ -204,6 +420,37 @@ func TestSignUpUserInvalid(t *testing.T) {
 	assert.Equal(t, "\"error message\"\n", rec.Body.String())
 }
 
+func TestSignUpPasswordInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/signup",
+		strings.NewReader("{\"login\":\"valid login\",\"password\":\"weak\",\"email\":\"validemail@email.com\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockAuth := domain.Auth{Login: "valid login", Password: "weak"}
+	mockUser := domain.User{Email: "validemail@email.com"}
+
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+	mockUserValidator.On("Validate", mock.Anything, &mockUser).Return(true, "")
+	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser, domain.SignUpInput{}).Return(nil, fmt.Errorf("%w: error message", domain.ErrWeakPassword))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, mockUserValidator)
+
+	handler.SignUp(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "error message")
+}
+
 func TestSignUpErrorOnSignUp(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
@@ -238,7 +485,7 @@ func TestSignUpErrorOnSignUp(t *testing.T) {
 		ZipCode:      "valid zipcode",
 	}
 
-	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser).Return("", errors.New("error message"))
+	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser, domain.SignUpInput{}).Return(nil, errors.New("error message"))
 	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
 	mockUserValidator.On("Validate", mock.Anything, &mockUser).Return(true, "")
 
@@ -250,7 +497,7 @@ func TestSignUpErrorOnSignUp(t *testing.T) {
 	assert.NotEqual(t, "", rec.Body.String())
 }
 
-func TestSignUpSuccess(t *testing.T) {
+func TestSignUpLoginTaken(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
 		echo.POST, "/signup",
@@ -284,7 +531,7 @@ func TestSignUpSuccess(t *testing.T) {
 		ZipCode:      "valid zipcode",
 	}
 
-	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser).Return("valid token", nil)
+	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser, domain.SignUpInput{}).Return(nil, fmt.Errorf("login exists: %w", domain.ErrLoginTaken))
 	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
 	mockUserValidator.On("Validate", mock.Anything, &mockUser).Return(true, "")
 
@@ -292,31 +539,15 @@ func TestSignUpSuccess(t *testing.T) {
 
 	handler.SignUp(c)
 
-	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
-}
-
-func TestForgotPassCodeWrongBody(t *testing.T) {
-	e := echo.New()
-	req, err := http.NewRequest(echo.POST, "/forgotpass/code", strings.NewReader("invalidbody"))
-	assert.NoError(t, err)
-
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-
-	handler := NewAuthHandler(echo.New(), nil, nil, nil)
-
-	handler.ForgotPassCode(c)
-
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusConflict, rec.Code)
 	assert.NotEqual(t, "", rec.Body.String())
 }
 
-func TestForgotPassCodeErrorInvalidLogin(t *testing.T) {
+func TestSignUpSuccess(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
-		echo.POST, "/forgotpass/code",
-		strings.NewReader("{\"login\":\"invalid login\""),
+		echo.POST, "/signup",
+		strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\",\"confirmPassword\":\"valid confirm password\",\"email\":\"validemail@email.com\",\"firstName\":\"valid first name\",\"lastName\":\"valid last name\",\"phoneNumber\":\"valid phone number\",\"address\":{\"city\":\"valid city\",\"state\":\"valid state\",\"neighborhood\":\"valid neighborhood\",\"street\":\"valid street\",\"number\":\"valid number\",\"zipcode\":\"valid zipcode\"}}"),
 	)
 	req.Header.Add("content-type", "application/json")
 	assert.NoError(t, err)
@@ -324,23 +555,45 @@ func TestForgotPassCodeErrorInvalidLogin(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
 	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockUserValidator := new(mocks.MockUserValidator)
 
-	mockAuthValidator.On("ValidateLogin", mock.Anything, "invalid login").Return(false, "error message")
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	handler := NewAuthHandler(echo.New(), nil, mockAuthValidator, nil)
+	var mockUser domain.User
+	mockUser.Email = "validemail@email.com"
+	mockUser.FirstName = "valid first name"
+	mockUser.LastName = "valid last name"
+	mockUser.PhoneNumber = "valid phone number"
+	mockUser.Address = domain.UserAddress{
+		City:         "valid city",
+		State:        "valid state",
+		Neighborhood: "valid neighborhood",
+		Street:       "valid street",
+		Number:       "valid number",
+		ZipCode:      "valid zipcode",
+	}
 
-	handler.ForgotPassCode(c)
+	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser, domain.SignUpInput{}).Return("valid token", "bearer", 0, nil)
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+	mockUserValidator.On("Validate", mock.Anything, &mockUser).Return(true, "")
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.NotEqual(t, "", rec.Body.String())
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, mockUserValidator)
+
+	handler.SignUp(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
 }
 
-func TestForgotPassCodeErrorSendingCode(t *testing.T) {
+func TestSignUpForwardsHoneypot(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
-		echo.POST, "/forgotpass/code",
-		strings.NewReader("{\"login\":\"valid login\"}"),
+		echo.POST, "/signup",
+		strings.NewReader("{\"login\":\"valid login\",\"password\":\"valid password\",\"confirmPassword\":\"valid confirm password\",\"email\":\"validemail@email.com\",\"firstName\":\"valid first name\",\"lastName\":\"valid last name\",\"phoneNumber\":\"valid phone number\",\"address\":{\"city\":\"valid city\",\"state\":\"valid state\",\"neighborhood\":\"valid neighborhood\",\"street\":\"valid street\",\"number\":\"valid number\",\"zipcode\":\"valid zipcode\"},\"website\":\"http://spam.example\"}"),
 	)
 	req.Header.Add("content-type", "application/json")
 	assert.NoError(t, err)
@@ -350,9 +603,95 @@ func TestForgotPassCodeErrorSendingCode(t *testing.T) {
 
 	mockAuthUsecase := new(mocks.MockAuthUsecase)
 	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockUserValidator := new(mocks.MockUserValidator)
 
-	mockAuthUsecase.On("ForgotPassCode", mock.Anything, "valid login").Return(errors.New("error message"))
-	mockAuthValidator.On("ValidateLogin", mock.Anything, "valid login").Return(true, "")
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "validemail@email.com"
+	mockUser.FirstName = "valid first name"
+	mockUser.LastName = "valid last name"
+	mockUser.PhoneNumber = "valid phone number"
+	mockUser.Address = domain.UserAddress{
+		City:         "valid city",
+		State:        "valid state",
+		Neighborhood: "valid neighborhood",
+		Street:       "valid street",
+		Number:       "valid number",
+		ZipCode:      "valid zipcode",
+	}
+
+	mockAuthUsecase.On("SignUp", mock.Anything, &mockAuth, &mockUser, domain.SignUpInput{Honeypot: "http://spam.example"}).Return("valid token", "bearer", 0, nil)
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+	mockUserValidator.On("Validate", mock.Anything, &mockUser).Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, mockUserValidator)
+
+	handler.SignUp(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
+}
+
+func TestForgotPassCodeWrongBody(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/forgotpass/code", strings.NewReader("invalidbody"))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewAuthHandler(echo.New(), nil, nil, nil)
+
+	handler.ForgotPassCode(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NotEqual(t, "", rec.Body.String())
+}
+
+func TestForgotPassCodeErrorInvalidLogin(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/code",
+		strings.NewReader("{\"login\":\"invalid login\""),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthValidator := new(mocks.MockAuthValidator)
+
+	mockAuthValidator.On("ValidateLogin", mock.Anything, "invalid login").Return(false, "error message")
+
+	handler := NewAuthHandler(echo.New(), nil, mockAuthValidator, nil)
+
+	handler.ForgotPassCode(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NotEqual(t, "", rec.Body.String())
+}
+
+func TestForgotPassCodeErrorSendingCode(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/code",
+		strings.NewReader("{\"login\":\"valid login\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+
+	mockAuthUsecase.On("ForgotPassCode", mock.Anything, "valid login", "").Return(errors.New("error message"))
+	mockAuthValidator.On("ValidateLogin", mock.Anything, "valid login").Return(true, "")
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
 
@@ -362,6 +701,31 @@ func TestForgotPassCodeErrorSendingCode(t *testing.T) {
 	assert.NotEqual(t, "", rec.Body.String())
 }
 
+func TestForgotPassCodeTooManyRequests(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/code",
+		strings.NewReader("{\"login\":\"valid login\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+
+	mockAuthUsecase.On("ForgotPassCode", mock.Anything, "valid login", "").Return(fmt.Errorf("wrapped: %w", domain.ErrTooManyRequests))
+	mockAuthValidator.On("ValidateLogin", mock.Anything, "valid login").Return(true, "")
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.ForgotPassCode(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
 func TestForgotPassCodeSuccess(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
@@ -377,7 +741,7 @@ func TestForgotPassCodeSuccess(t *testing.T) {
 	mockAuthUsecase := new(mocks.MockAuthUsecase)
 	mockAuthValidator := new(mocks.MockAuthValidator)
 
-	mockAuthUsecase.On("ForgotPassCode", mock.Anything, "valid login").Return(nil)
+	mockAuthUsecase.On("ForgotPassCode", mock.Anything, "valid login", "").Return(nil)
 	mockAuthValidator.On("ValidateLogin", mock.Anything, "valid login").Return(true, "")
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
@@ -473,7 +837,7 @@ func TestForgotPassResetErrorResettingPassword(t *testing.T) {
 
 	code := domain.Code{Value: "valid code", Identifier: mockAuth.Login}
 
-	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password).Return("", errors.New("error message"))
+	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password, "").Return(nil, errors.New("error message"))
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
 
@@ -483,6 +847,99 @@ func TestForgotPassResetErrorResettingPassword(t *testing.T) {
 	assert.NotEqual(t, "", rec.Body.String())
 }
 
+func TestForgotPassResetCodeExpired(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/reset",
+		strings.NewReader("{\"login\":\"valid login\",\"code\":\"valid code\",\"newPassword\":\"valid new password\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid new password"
+
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	code := domain.Code{Value: "valid code", Identifier: mockAuth.Login}
+
+	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password, "").Return(nil, domain.ErrCodeExpired)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.ForgotPassReset(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestForgotPassResetPasswordInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/reset",
+		strings.NewReader("{\"login\":\"valid login\",\"code\":\"valid code\",\"newPassword\":\"weak\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockAuth := domain.Auth{Login: "valid login", Password: "weak"}
+
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	code := domain.Code{Value: "valid code", Identifier: mockAuth.Login}
+
+	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password, "").Return(nil, fmt.Errorf("%w: error message", domain.ErrWeakPassword))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.ForgotPassReset(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "error message")
+}
+
+func TestForgotPassResetUnknownClient(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(
+		echo.POST, "/forgotpass/reset",
+		strings.NewReader("{\"login\":\"valid login\",\"code\":\"valid code\",\"newPassword\":\"valid new password\",\"client\":\"desktop\"}"),
+	)
+	req.Header.Add("content-type", "application/json")
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid new password"}
+
+	mockAuthValidator.On("Validate", mock.Anything, &mockAuth).Return(true, "")
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	code := domain.Code{Value: "valid code", Identifier: mockAuth.Login}
+
+	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password, "desktop").Return(nil, domain.ErrUnknownClient)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
+
+	handler.ForgotPassReset(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestForgotPassResetSuccess(t *testing.T) {
 	e := echo.New()
 	req, err := http.NewRequest(
@@ -506,7 +963,7 @@ func TestForgotPassResetSuccess(t *testing.T) {
 
 	code := domain.Code{Value: "valid code", Identifier: mockAuth.Login}
 
-	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password).Return("valid token", nil)
+	mockAuthUsecase.On("ForgotPassReset", mock.Anything, &code, mockAuth.Password, "").Return("valid token", "bearer", 0, nil)
 
 	handler := NewAuthHandler(echo.New(), mockAuthUsecase, mockAuthValidator, nil)
 
@@ -516,3 +973,232 @@ func TestForgotPassResetSuccess(t *testing.T) {
 	assert.Equal(t, "{\"token\":\"valid token\"}\n", rec.Body.String())
 
 }
+
+func TestLogoutWithoutToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/logout", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockAuthUsecase.AssertNotCalled(t, "Logout", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogoutErrorOnLogout(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/logout", nil)
+	assert.NoError(t, err)
+	req.Header.Add("Authorization", "valid token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("Logout", mock.Anything, domain.Token("valid token"), domain.Token("")).Return(errors.New("error message"))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestLogoutSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/logout", nil)
+	assert.NoError(t, err)
+	req.Header.Add("Authorization", "valid token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("Logout", mock.Anything, domain.Token("valid token"), domain.Token("")).Return(nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Set-Cookie"))
+}
+
+func TestLogoutSuccessCookie(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/logout", nil)
+	assert.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: domain.TokenCookieName, Value: "valid token"})
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("Logout", mock.Anything, domain.Token("valid token"), domain.Token("")).Return(nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 2)
+	assert.Equal(t, domain.TokenCookieName, cookies[0].Name)
+	assert.Equal(t, "", cookies[0].Value)
+	assert.Equal(t, -1, cookies[0].MaxAge)
+	assert.Equal(t, domain.RefreshTokenCookieName, cookies[1].Name)
+	assert.Equal(t, "/refresh", cookies[1].Path)
+	assert.Equal(t, -1, cookies[1].MaxAge)
+}
+
+func TestLogoutForwardsRefreshToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/logout", strings.NewReader("{\"refreshToken\":\"refresh token\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+	req.Header.Add("Authorization", "valid token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("Logout", mock.Anything, domain.Token("valid token"), domain.Token("refresh token")).Return(nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.Logout(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockAuthUsecase.AssertExpectations(t)
+}
+
+func TestRefreshTokenMissing(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/refresh", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.RefreshToken(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockAuthUsecase.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenErrorOnRefresh(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/refresh", strings.NewReader("{\"refreshToken\":\"refresh token\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("RefreshToken", mock.Anything, domain.Token("refresh token")).Return(nil, errors.New("error message"))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.RefreshToken(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestRefreshTokenSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/refresh", strings.NewReader("{\"refreshToken\":\"refresh token\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("RefreshToken", mock.Anything, domain.Token("refresh token")).Return("new token", "new refresh token", "bearer", 0, nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.RefreshToken(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"refreshToken\":\"new refresh token\",\"token\":\"new token\"}\n", rec.Body.String())
+}
+
+func TestVerifyEmailMissingCode(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/verifyemail", strings.NewReader("{\"login\":\"valid login\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.VerifyEmail(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockAuthUsecase.AssertNotCalled(t, "VerifyEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailErrorOnVerify(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/verifyemail", strings.NewReader("{\"login\":\"valid login\",\"code\":\"123456\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyEmail", mock.Anything, "valid login", "123456").Return(errors.New("error message"))
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.VerifyEmail(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestVerifyEmailSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/verifyemail", strings.NewReader("{\"login\":\"valid login\",\"code\":\"123456\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyEmail", mock.Anything, "valid login", "123456").Return(nil)
+
+	handler := NewAuthHandler(echo.New(), mockAuthUsecase, nil, nil)
+
+	handler.VerifyEmail(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,45 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// NewAuthMiddleware only lets requests with a valid token through, the decoded
+// token is put in the request context for the use cases to authorize the caller.
+func NewAuthMiddleware(auc domain.AuthUseCase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := c.Request().Header.Get("Authorization")
+
+			if token == "" {
+				if cookie, err := c.Cookie(domain.TokenCookieName); err == nil {
+					token = cookie.Value
+				}
+			}
+
+			if token == "" {
+				return c.JSON(http.StatusUnauthorized, "request not authorized")
+			}
+
+			info, err := auc.VerifyToken(c.Request().Context(), domain.Token(token))
+
+			if errors.Is(err, domain.ErrInvalidToken) {
+				return c.JSON(http.StatusUnauthorized, "request not authorized")
+			}
+
+			if err != nil {
+				log.Printf("Error trying to verify token: %s", err.Error())
+				return c.JSON(http.StatusInternalServerError, "failed to authorize request")
+			}
+
+			c.SetRequest(c.Request().WithContext(domain.WithTokenInfo(c.Request().Context(), info)))
+
+			return next(c)
+		}
+	}
+}
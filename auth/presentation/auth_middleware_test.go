@@ -0,0 +1,96 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// callerHandler answers with the login and role the middleware put in the context.
+func callerHandler(c echo.Context) error {
+	info := domain.TokenInfoFromContext(c.Request().Context())
+	return c.String(http.StatusOK, info.Info+" "+info.Role)
+}
+
+func TestAuthMiddlewareWithoutToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	NewAuthMiddleware(mockAuthUsecase)(callerHandler)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockAuthUsecase.AssertNotCalled(t, "VerifyToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthMiddlewareInvalidToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	req.Header.Set("Authorization", "expired token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyToken", mock.Anything, domain.Token("expired token")).Return("", "", fmt.Errorf("%w: token is expired", domain.ErrInvalidToken))
+
+	NewAuthMiddleware(mockAuthUsecase)(callerHandler)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthMiddlewareVerifyTokenError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	req.Header.Set("Authorization", "valid token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyToken", mock.Anything, domain.Token("valid token")).Return("", "", errors.New("error message"))
+
+	NewAuthMiddleware(mockAuthUsecase)(callerHandler)(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestAuthMiddlewareStoresTokenInfo(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	req.AddCookie(&http.Cookie{Name: domain.TokenCookieName, Value: "valid token"})
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyToken", mock.Anything, domain.Token("valid token")).Return("valid login", domain.RoleCustomer, nil)
+
+	NewAuthMiddleware(mockAuthUsecase)(callerHandler)(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "valid login customer", rec.Body.String())
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 )
 
@@ -18,13 +21,13 @@ func NewAuthMysqlRepository(conn *sql.DB) domain.AuthRepository {
 }
 
 func (r *authMysqlRepository) GetByLogin(ctx context.Context, login string) (*domain.Auth, error) {
-	query := `SELECT id, uuid, login, password FROM auth WHERE login = ?;`
+	query := `SELECT id, uuid, login, password, role, verified FROM auth WHERE login = ?;`
 
 	row := r.Conn.QueryRowContext(ctx, query, login)
 
 	var res domain.Auth
 
-	if err := row.Scan(&res.ID, &res.UUID, &res.Login, &res.Password); err != nil {
+	if err := row.Scan(&res.ID, &res.UUID, &res.Login, &res.Password, &res.Role, &res.Verified); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -36,8 +39,8 @@ func (r *authMysqlRepository) GetByLogin(ctx context.Context, login string) (*do
 }
 
 func (r *authMysqlRepository) StoreWithUser(ctx context.Context, a *domain.Auth, u *domain.User) error {
-	storeUserQuery := `INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
-	storeAuthQuery := `INSERT INTO auth (uuid, login, password) VALUES (?, ?, ?);`
+	storeUserQuery := `INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, terms_version, terms_accepted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	storeAuthQuery := `INSERT INTO auth (uuid, login, password, role) VALUES (?, ?, ?, ?);`
 
 	tx, err := r.Conn.BeginTx(ctx, nil)
 
@@ -52,8 +55,13 @@ func (r *authMysqlRepository) StoreWithUser(ctx context.Context, a *domain.Auth,
 	}
 
 	u.UUID = uuid.NewString()
-	if _, err = storeUserStmt.ExecContext(ctx, u.UUID, u.Email, u.FirstName, u.LastName, u.PhoneNumber, u.Address.City, u.Address.State, u.Address.Neighborhood, u.Address.Street, u.Address.Number, u.Address.ZipCode); err != nil {
+	if _, err = storeUserStmt.ExecContext(ctx, u.UUID, u.Email, u.FirstName, u.LastName, u.PhoneNumber, u.Address.City, u.Address.State, u.Address.Neighborhood, u.Address.Street, u.Address.Number, u.Address.ZipCode, u.TermsVersion, sql.NullTime{Time: u.TermsAcceptedAt, Valid: !u.TermsAcceptedAt.IsZero()}); err != nil {
 		tx.Rollback()
+
+		if isDuplicatePhone(err) {
+			return fmt.Errorf("user with phone number %s already exists: %w", u.PhoneNumber, domain.ErrPhoneTaken)
+		}
+
 		return err
 	}
 
@@ -64,7 +72,7 @@ func (r *authMysqlRepository) StoreWithUser(ctx context.Context, a *domain.Auth,
 	}
 
 	a.UUID = uuid.NewString()
-	if _, err = storeAuthStmt.ExecContext(ctx, a.UUID, a.Login, a.Password); err != nil {
+	if _, err = storeAuthStmt.ExecContext(ctx, a.UUID, a.Login, a.Password, a.Role); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -103,3 +111,81 @@ func (r *authMysqlRepository) Update(ctx context.Context, a *domain.Auth) error
 
 	return nil
 }
+
+func (r *authMysqlRepository) Count(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM auth;`
+
+	row := r.Conn.QueryRowContext(ctx, query)
+
+	var total int64
+
+	if err := row.Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *authMysqlRepository) MarkVerified(ctx context.Context, login string) error {
+	query := `UPDATE auth SET verified = 1 WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to mark auth as verified with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *authMysqlRepository) UpdatePassword(ctx context.Context, login string, password string) error {
+	query := `UPDATE auth SET password = ? WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, password, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to update auth password with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// isDuplicatePhone tells whether err is MySQL rejecting a row for the
+// user_phone_number_UN constraint.
+func isDuplicatePhone(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 && strings.Contains(mysqlErr.Message, "user_phone_number_UN")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,9 +19,9 @@ func TestGetByLoginNotFound(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password"})
+	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password", "role", "verified"})
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role, verified FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -43,7 +44,7 @@ func TestGetByLoginError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role, verified FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -65,9 +66,9 @@ func TestGetByLogin(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password"}).AddRow(1, "uuid", "login", "password")
+	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password", "role", "verified"}).AddRow(1, "uuid", "login", "password", "customer", true)
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role, verified FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -80,6 +81,8 @@ func TestGetByLogin(t *testing.T) {
 	assert.Equal(t, "uuid", auth.UUID)
 	assert.Equal(t, "login", auth.Login)
 	assert.Equal(t, "password", auth.Password)
+	assert.Equal(t, "customer", auth.Role)
+	assert.True(t, auth.Verified)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Error(err)
@@ -93,11 +96,11 @@ func TestStoreWithUserStoreUserError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+	query := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, terms_version, terms_accepted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
 
 	mock.ExpectBegin()
 	mock.ExpectPrepare(query)
-	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "").WillReturnError(errors.New("error message"))
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "", "", sqlmock.AnyArg()).WillReturnError(errors.New("error message"))
 	mock.ExpectRollback()
 
 	authMysqlRepository := NewAuthMysqlRepository(db)
@@ -111,6 +114,31 @@ func TestStoreWithUserStoreUserError(t *testing.T) {
 	}
 }
 
+func TestStoreWithUserDuplicatePhone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, terms_version, terms_accepted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "", "", sqlmock.AnyArg()).WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'phone_number' for key 'user_phone_number_UN'"})
+	mock.ExpectRollback()
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	err = authMysqlRepository.StoreWithUser(context.Background(), &domain.Auth{}, &domain.User{})
+
+	assert.ErrorIs(t, err, domain.ErrPhoneTaken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestStoreWithUserStoreAuthError(t *testing.T) {
 	db, mock, err := sqlmock.New()
 
@@ -118,14 +146,14 @@ func TestStoreWithUserStoreAuthError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	storeUserQuery := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
-	storeAuthQuery := regexp.QuoteMeta("INSERT INTO auth (uuid, login, password) VALUES (?, ?, ?);")
+	storeUserQuery := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, terms_version, terms_accepted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+	storeAuthQuery := regexp.QuoteMeta("INSERT INTO auth (uuid, login, password, role) VALUES (?, ?, ?, ?);")
 
 	mock.ExpectBegin()
 	mock.ExpectPrepare(storeUserQuery)
-	mock.ExpectExec(storeUserQuery).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(storeUserQuery).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "", "", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectPrepare(storeAuthQuery)
-	mock.ExpectExec(storeAuthQuery).WithArgs(sqlmock.AnyArg(), "", "").WillReturnError(errors.New("error message"))
+	mock.ExpectExec(storeAuthQuery).WithArgs(sqlmock.AnyArg(), "", "", "").WillReturnError(errors.New("error message"))
 	mock.ExpectRollback()
 
 	authMysqlRepository := NewAuthMysqlRepository(db)
@@ -146,14 +174,14 @@ func TestStoreWithUser(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	storeUserQuery := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
-	storeAuthQuery := regexp.QuoteMeta("INSERT INTO auth (uuid, login, password) VALUES (?, ?, ?);")
+	storeUserQuery := regexp.QuoteMeta("INSERT INTO users (uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, terms_version, terms_accepted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")
+	storeAuthQuery := regexp.QuoteMeta("INSERT INTO auth (uuid, login, password, role) VALUES (?, ?, ?, ?);")
 
 	mock.ExpectBegin()
 	mock.ExpectPrepare(storeUserQuery)
-	mock.ExpectExec(storeUserQuery).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(storeUserQuery).WithArgs(sqlmock.AnyArg(), "", "", "", "", "", "", "", "", "", "", "", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectPrepare(storeAuthQuery)
-	mock.ExpectExec(storeAuthQuery).WithArgs(sqlmock.AnyArg(), "", "").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(storeAuthQuery).WithArgs(sqlmock.AnyArg(), "", "", "").WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	authMysqlRepository := NewAuthMysqlRepository(db)
@@ -212,3 +240,142 @@ func TestUpdate(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestCountError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM auth;")
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	_, err = authMysqlRepository.Count(context.Background())
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3)
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM auth;")
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	total, err := authMysqlRepository.Count(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMarkVerifiedError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE auth SET verified = 1 WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnError(errors.New("error message"))
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	err = authMysqlRepository.MarkVerified(context.Background(), "login")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMarkVerified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE auth SET verified = 1 WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	err = authMysqlRepository.MarkVerified(context.Background(), "login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdatePasswordError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE auth SET password = ? WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("password", "login").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	err = authMysqlRepository.UpdatePassword(context.Background(), "login", "password")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE auth SET password = ? WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("password", "login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	authMysqlRepository := NewAuthMysqlRepository(db)
+
+	err = authMysqlRepository.UpdatePassword(context.Background(), "login", "password")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
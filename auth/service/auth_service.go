@@ -2,22 +2,47 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-type authService struct{}
+const defaultCost = 14
 
-func NewAuthService() *authService {
-	return &authService{}
+type authService struct {
+	cost int
 }
 
-func (a authService) EncodePass(ctx context.Context, pass string) string {
-	bytes, _ := bcrypt.GenerateFromPassword([]byte(pass), 14)
-	return string(bytes)
+// NewAuthService hashes passwords with the given bcrypt cost, 0 keeps the default of 14.
+func NewAuthService(cost int) (*authService, error) {
+	if cost == 0 {
+		cost = defaultCost
+	}
+
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("password hash cost %d is out of the bcrypt range %d-%d", cost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return &authService{cost: cost}, nil
+}
+
+func (a authService) EncodePass(ctx context.Context, pass string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(pass), a.cost)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
 }
 
 func (a authService) PassIsEqualHashedPass(ctx context.Context, pass string, hashedPass string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPass), []byte(pass))
 	return err == nil
 }
+
+// NeedsRehash tells if the hash was made with a lower cost than the current one.
+func (a authService) NeedsRehash(ctx context.Context, hashedPass string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPass))
+	return err == nil && cost < a.cost
+}
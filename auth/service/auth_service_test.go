@@ -5,18 +5,75 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
+func newAuthService(t *testing.T, cost int) *authService {
+	authService, err := NewAuthService(cost)
+
+	assert.NoError(t, err)
+
+	return authService
+}
+
+func encodePass(t *testing.T, authService *authService, pass string) string {
+	encodedPass, err := authService.EncodePass(context.Background(), pass)
+
+	assert.NoError(t, err)
+
+	return encodedPass
+}
+
 func TestEncodePass(t *testing.T) {
-	authService := NewAuthService()
-	encodedPass := authService.EncodePass(context.Background(), "password")
+	authService := newAuthService(t, 0)
+
+	encodedPass := encodePass(t, authService, "password")
+
 	assert.NotEmpty(t, encodedPass)
 	assert.NotEqual(t, "password", encodedPass)
+
+	cost, err := bcrypt.Cost([]byte(encodedPass))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 14, cost)
+}
+
+func TestEncodePassConfiguredCost(t *testing.T) {
+	authService := newAuthService(t, bcrypt.MinCost)
+
+	cost, err := bcrypt.Cost([]byte(encodePass(t, authService, "password")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost, cost)
+}
+
+func TestNewAuthServiceCostOutOfRange(t *testing.T) {
+	for _, cost := range []int{-1, bcrypt.MinCost - 1, bcrypt.MaxCost + 1} {
+		authService, err := NewAuthService(cost)
+
+		assert.Error(t, err)
+		assert.Nil(t, authService)
+	}
 }
 
 func TestPassIsEqualHashedPass(t *testing.T) {
-	authService := NewAuthService()
-	encodedPass := authService.EncodePass(context.Background(), "password")
+	authService := newAuthService(t, 0)
+
+	encodedPass := encodePass(t, authService, "password")
+
 	isEncoded := authService.PassIsEqualHashedPass(context.Background(), "password", encodedPass)
+
 	assert.True(t, isEncoded)
 }
+
+func TestNeedsRehash(t *testing.T) {
+	lowCostPass := encodePass(t, newAuthService(t, bcrypt.MinCost), "password")
+	highCostPass := encodePass(t, newAuthService(t, bcrypt.MinCost+2), "password")
+
+	authService := newAuthService(t, bcrypt.MinCost+1)
+
+	assert.True(t, authService.NeedsRehash(context.Background(), lowCostPass))
+	assert.False(t, authService.NeedsRehash(context.Background(), highCostPass))
+	assert.False(t, newAuthService(t, bcrypt.MinCost).NeedsRehash(context.Background(), lowCostPass))
+	assert.False(t, authService.NeedsRehash(context.Background(), "not a hash"))
+}
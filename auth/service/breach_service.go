@@ -0,0 +1,63 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const HIBPRangeURL = "https://api.pwnedpasswords.com/range/"
+
+type breachService struct {
+	client   *http.Client
+	rangeURL string
+}
+
+// NewBreachService checks passwords against a k-anonymity range API such as
+// HaveIBeenPwned, only the first 5 characters of the SHA-1 hash leave the server.
+func NewBreachService(client *http.Client, rangeURL string) *breachService {
+	return &breachService{client: client, rangeURL: rangeURL}
+}
+
+func (b breachService) IsBreached(ctx context.Context, pass string) (bool, error) {
+	sum := sha1.Sum([]byte(pass))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.rangeURL+prefix, nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Add-Padding", "true")
+
+	res, err := b.client.Do(req)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breach range request failed with status: %d", res.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)
+
+		// padding entries have a count of 0
+		if len(parts) == 2 && strings.EqualFold(parts[0], suffix) && parts[1] != "0" {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRangeServer(t *testing.T, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// sha1 of "password" starts with 5BAA6, only that prefix may be sent
+		assert.Equal(t, "/range/5BAA6", r.URL.Path)
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestIsBreachedKnownPassword(t *testing.T) {
+	server := newRangeServer(t, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n")
+	defer server.Close()
+
+	isBreached, err := NewBreachService(server.Client(), server.URL+"/range/").IsBreached(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.True(t, isBreached)
+}
+
+func TestIsBreachedPaddingEntry(t *testing.T) {
+	server := newRangeServer(t, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:0\r\n")
+	defer server.Close()
+
+	isBreached, err := NewBreachService(server.Client(), server.URL+"/range/").IsBreached(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.False(t, isBreached)
+}
+
+func TestIsBreachedUnknownPassword(t *testing.T) {
+	server := newRangeServer(t, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n")
+	defer server.Close()
+
+	isBreached, err := NewBreachService(server.Client(), server.URL+"/range/").IsBreached(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.False(t, isBreached)
+}
+
+func TestIsBreachedRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewBreachService(server.Client(), server.URL+"/range/").IsBreached(context.Background(), "password")
+
+	assert.Error(t, err)
+}
@@ -2,11 +2,37 @@ package usecase
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
 
+const defaultTokenTTLMinutes int64 = 43200
+
+const defaultResetCodeTTLMinutes int64 = 30
+
+const defaultResetCodeCooldownSeconds int64 = 60
+
+const twoFactorChallengeTTLMinutes int64 = 5
+
+const maxEmailVerificationAttempts = 5
+
+const defaultTwoFactorIssuer = "e-commerce"
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
 type authUseCase struct {
 	authService    domain.AuthService
 	tokenService   domain.TokenService
@@ -14,9 +40,20 @@ type authUseCase struct {
 	messageService domain.MessageService
 	authRepo       domain.AuthRepository
 	userRepo       domain.UserRepository
+	creditRepo     domain.CreditRepository
+	revokedRepo    domain.RevokedTokenRepository
+	refreshRepo    domain.RefreshTokenRepository
+	verifyRepo     domain.EmailVerificationRepository
+	twoFactorRepo  domain.TwoFactorRepository
+	authValidator  domain.AuthValidator
+	conf           domain.AuthConfig
+	now            func() time.Time
+	sleep          func(time.Duration)
+	dummyHashOnce  sync.Once
+	dummyHash      string
 }
 
-func NewAuthUseCase(as domain.AuthService, ts domain.TokenService, cs domain.CodeService, ms domain.MessageService, ar domain.AuthRepository, ur domain.UserRepository) domain.AuthUseCase {
+func NewAuthUseCase(as domain.AuthService, ts domain.TokenService, cs domain.CodeService, ms domain.MessageService, ar domain.AuthRepository, ur domain.UserRepository, cr domain.CreditRepository, rr domain.RevokedTokenRepository, rtr domain.RefreshTokenRepository, evr domain.EmailVerificationRepository, tfr domain.TwoFactorRepository, av domain.AuthValidator, conf domain.AuthConfig) domain.AuthUseCase {
 	return &authUseCase{
 		authService:    as,
 		tokenService:   ts,
@@ -24,82 +61,583 @@ func NewAuthUseCase(as domain.AuthService, ts domain.TokenService, cs domain.Cod
 		messageService: ms,
 		authRepo:       ar,
 		userRepo:       ur,
+		creditRepo:     cr,
+		revokedRepo:    rr,
+		refreshRepo:    rtr,
+		verifyRepo:     evr,
+		twoFactorRepo:  tfr,
+		authValidator:  av,
+		conf:           conf,
+		now:            time.Now,
+		sleep:          time.Sleep,
+	}
+}
+
+// tokenTTL gives the lifetime of tokens issued to role, used both to sign
+// them and as the cookie MaxAge.
+func (au *authUseCase) tokenTTL(role string) int64 {
+	if roleTTL := au.conf.RoleTTLMinutes[role]; roleTTL > 0 {
+		return roleTTL
+	}
+
+	if au.conf.TokenTTLMinutes > 0 {
+		return au.conf.TokenTTLMinutes
+	}
+
+	return defaultTokenTTLMinutes
+}
+
+func (au *authUseCase) resetCodeTTL() time.Duration {
+	minutes := au.conf.ResetCodeTTLMinutes
+
+	if minutes <= 0 {
+		minutes = defaultResetCodeTTLMinutes
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+func (au *authUseCase) resetCodeCooldown() time.Duration {
+	seconds := au.conf.ResetCodeCooldownSeconds
+
+	if seconds <= 0 {
+		seconds = defaultResetCodeCooldownSeconds
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+func (au *authUseCase) newAuthResult(token domain.Token, expirationInMinutes int64) *domain.AuthResult {
+	result := &domain.AuthResult{TokenPair: domain.TokenPair{Token: token}, Transport: au.conf.TokenTransport}
+
+	if result.Transport == "" {
+		result.Transport = domain.TokenTransportBearer
+	}
+
+	if result.Transport == domain.TokenTransportCookie {
+		result.MaxAge = expirationInMinutes * 60
 	}
+
+	return result
+}
+
+// randomToken is opaque and unsigned, the token service never accepts it.
+func randomToken() domain.Token {
+	b := make([]byte, 96)
+	rand.Read(b)
+	return domain.Token(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// attachRefreshToken issues and stores a refresh token for login when refresh tokens are enabled.
+func (au *authUseCase) attachRefreshToken(ctx context.Context, result *domain.AuthResult, login string, audience string) error {
+	if au.conf.RefreshTokenTTLMinutes <= 0 {
+		return nil
+	}
+
+	refresh := domain.RefreshToken{
+		Value:     string(randomToken()),
+		Login:     login,
+		Audience:  audience,
+		ExpiresAt: au.now().Add(time.Duration(au.conf.RefreshTokenTTLMinutes) * time.Minute),
+	}
+
+	if err := au.refreshRepo.Store(ctx, &refresh); err != nil {
+		return err
+	}
+
+	result.RefreshToken = domain.Token(refresh.Value)
+
+	if result.Transport == domain.TokenTransportCookie {
+		result.RefreshMaxAge = au.conf.RefreshTokenTTLMinutes * 60
+	}
+
+	return nil
+}
+
+// waitAtLeast sleeps for whatever is left of min since start.
+func (au *authUseCase) waitAtLeast(start time.Time, min time.Duration) {
+	if elapsed := au.now().Sub(start); elapsed < min {
+		au.sleep(min - elapsed)
+	}
+}
+
+// verificationCode is a six digit code the user types back from the email.
+func verificationCode() string {
+	n, _ := rand.Int(rand.Reader, big.NewInt(1000000))
+	return fmt.Sprintf("%06d", n.Int64())
 }
 
-func (au *authUseCase) Login(ctx context.Context, a *domain.Auth) (domain.Token, error) {
+// totpCode is the RFC 6238 code of the secret for the 30 second step holding t.
+func totpCode(secret []byte, t time.Time) string {
+	var counter [8]byte
+
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/30))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// validTOTP also takes the codes of the steps next to the current one, so
+// some clock drift on the authenticator app is tolerated.
+func validTOTP(encodedSecret string, code string, now time.Time) bool {
+	secret, err := totpEncoding.DecodeString(encodedSecret)
+
+	if err != nil {
+		return false
+	}
+
+	for _, drift := range []time.Duration{0, -30 * time.Second, 30 * time.Second} {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, now.Add(drift))), []byte(code)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (au *authUseCase) twoFactorIssuer() string {
+	if au.conf.TwoFactorIssuer == "" {
+		return defaultTwoFactorIssuer
+	}
+
+	return au.conf.TwoFactorIssuer
+}
+
+func (au *authUseCase) sendEmailVerification(ctx context.Context, login string, email string) error {
+	verification := domain.EmailVerification{
+		Login:     login,
+		Code:      verificationCode(),
+		ExpiresAt: au.now().Add(time.Duration(au.conf.EmailVerificationTTLMinutes) * time.Minute),
+	}
+
+	if err := au.verifyRepo.Store(ctx, &verification); err != nil {
+		return err
+	}
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "email"
+	messageConf.To = email
+	messageConf.Subject = "Confirmação de email"
+	messageConf.Message = fmt.Sprintf("O código para confirmar seu email é %s", verification.Code)
+
+	return au.messageService.SendMessage(ctx, &messageConf)
+}
+
+func (au *authUseCase) Login(ctx context.Context, a *domain.Auth, in domain.LoginInput) (*domain.AuthResult, error) {
+	if au.conf.LoginMinDurationMillis > 0 {
+		defer au.waitAtLeast(au.now(), time.Duration(au.conf.LoginMinDurationMillis)*time.Millisecond)
+	}
+
+	audience, err := au.clientAudience(in.Client)
+
+	if err != nil {
+		return nil, err
+	}
+
 	auth, err := au.authRepo.GetByLogin(ctx, a.Login)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if auth == nil {
-		return "", fmt.Errorf("auth with login %s not found", a.Login)
+		au.authService.PassIsEqualHashedPass(ctx, a.Password, au.dummyPasswordHash(ctx))
+		return nil, fmt.Errorf("auth with login %s not found: %w", a.Login, domain.ErrInvalidCredentials)
 	}
 
 	if !au.authService.PassIsEqualHashedPass(ctx, a.Password, auth.Password) {
-		return "", fmt.Errorf("wrong password for login %s", a.Login)
+		return nil, fmt.Errorf("wrong password for login %s: %w", a.Login, domain.ErrInvalidCredentials)
+	}
+
+	if au.authService.NeedsRehash(ctx, auth.Password) {
+		// the login is already proven, failing to upgrade the hash only
+		// postpones it to the next login so the error is not surfaced
+		if hash, err := au.authService.EncodePass(ctx, a.Password); err == nil && hash != "" {
+			au.authRepo.UpdatePassword(ctx, a.Login, hash)
+		}
+	}
+
+	twoFactor, err := au.twoFactorEnabled(ctx, a.Login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor {
+		return au.startTwoFactorLogin(ctx, a.Login, audience)
+	}
+
+	return au.issueLoginResult(ctx, auth, audience)
+}
+
+// clientAudience gives the audience tokens issued to client carry, empty when
+// no client audiences are configured.
+func (au *authUseCase) clientAudience(client string) (string, error) {
+	if len(au.conf.ClientAudiences) == 0 {
+		return "", nil
+	}
+
+	audience, ok := au.conf.ClientAudiences[client]
+
+	if !ok {
+		return "", fmt.Errorf("%w: client %s can not login", domain.ErrUnknownClient, client)
 	}
 
+	return audience, nil
+}
+
+// audienceAllowed tells whether a token audience belongs to one of the
+// configured clients, any audience is when none are configured.
+func (au *authUseCase) audienceAllowed(audience string) bool {
+	if len(au.conf.ClientAudiences) == 0 {
+		return true
+	}
+
+	for _, clientAudience := range au.conf.ClientAudiences {
+		if clientAudience == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dummyPasswordHash is compared against for unknown logins so they cost the same
+// bcrypt time as a wrong password, it is hashed once with the configured cost.
+func (au *authUseCase) dummyPasswordHash(ctx context.Context) string {
+	au.dummyHashOnce.Do(func() {
+		// an empty hash only makes unknown logins fail faster, the login is
+		// rejected either way so the error is not surfaced
+		au.dummyHash, _ = au.authService.EncodePass(ctx, "dummy password")
+	})
+
+	return au.dummyHash
+}
+
+// twoFactorEnabled tells if a login has to finish logging in with a TOTP code
+// before it gets a token.
+func (au *authUseCase) twoFactorEnabled(ctx context.Context, login string) (bool, error) {
+	if !au.conf.TwoFactor {
+		return false, nil
+	}
+
+	twoFactor, err := au.twoFactorRepo.GetByLogin(ctx, login)
+
+	if err != nil {
+		return false, err
+	}
+
+	return twoFactor != nil && twoFactor.Enabled, nil
+}
+
+func (au *authUseCase) issueLoginResult(ctx context.Context, auth *domain.Auth, audience string) (*domain.AuthResult, error) {
 	var tokenInfo domain.TokenInfo
 
-	tokenInfo.Info = a.Login
+	tokenInfo.Info = auth.Login
+	tokenInfo.Role = auth.Role
+	tokenInfo.Audience = audience
+	tokenInfo.Verified = auth.Verified
+
+	tokenTTL := au.tokenTTL(auth.Role)
+
+	token, err := au.tokenService.Sign(ctx, tokenInfo, tokenTTL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := au.newAuthResult(token, tokenTTL)
+
+	if err := au.attachRefreshToken(ctx, result, auth.Login, audience); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// startTwoFactorLogin answers a right password with a short lived opaque
+// token, LoginTwoFactor exchanges it together with a TOTP code for the real one.
+func (au *authUseCase) startTwoFactorLogin(ctx context.Context, login string, audience string) (*domain.AuthResult, error) {
+	challenge := domain.TwoFactorChallenge{
+		Value:     string(randomToken()),
+		Login:     login,
+		Audience:  audience,
+		ExpiresAt: au.now().Add(time.Duration(twoFactorChallengeTTLMinutes) * time.Minute),
+	}
+
+	if err := au.twoFactorRepo.StoreChallenge(ctx, &challenge); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResult{TwoFactorToken: domain.Token(challenge.Value)}, nil
+}
+
+// LoginTwoFactor takes a single code per two factor token, a wrong code means
+// logging in with the password again so codes can not be guessed with it.
+func (au *authUseCase) LoginTwoFactor(ctx context.Context, twoFactorToken domain.Token, code string) (*domain.AuthResult, error) {
+	challenge, err := au.twoFactorRepo.GetChallengeByValue(ctx, string(twoFactorToken))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge == nil {
+		return nil, fmt.Errorf("%w: unknown two factor token", domain.ErrInvalidToken)
+	}
+
+	if err := au.twoFactorRepo.DeleteChallengeByValue(ctx, challenge.Value); err != nil {
+		return nil, err
+	}
+
+	if au.now().After(challenge.ExpiresAt) {
+		return nil, fmt.Errorf("%w: two factor token for %s expired", domain.ErrInvalidToken, challenge.Login)
+	}
+
+	if err := au.VerifyTwoFactor(ctx, challenge.Login, code); err != nil {
+		return nil, err
+	}
+
+	auth, err := au.authRepo.GetByLogin(ctx, challenge.Login)
 
-	var thirtyDaysInMinutes int64 = 43200
+	if err != nil {
+		return nil, err
+	}
 
-	token, err := au.tokenService.Sign(ctx, tokenInfo, thirtyDaysInMinutes)
+	if auth == nil {
+		return nil, fmt.Errorf("auth with login %s not found: %w", challenge.Login, domain.ErrInvalidCredentials)
+	}
+
+	return au.issueLoginResult(ctx, auth, challenge.Audience)
+}
+
+// EnableTwoFactor gives a new secret and its otpauth URL for the authenticator
+// app, two factor is only turned on once VerifyTwoFactor takes a code of it.
+func (au *authUseCase) EnableTwoFactor(ctx context.Context, login string) (string, string, error) {
+	if !au.conf.TwoFactor {
+		return "", "", fmt.Errorf("two factor is not available")
+	}
+
+	current, err := au.twoFactorRepo.GetByLogin(ctx, login)
 
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+
+	if current != nil && current.Enabled {
+		return "", "", fmt.Errorf("two factor is already enabled for login %s", login)
 	}
 
-	return token, nil
+	b := make([]byte, 20)
+	rand.Read(b)
+
+	secret := totpEncoding.EncodeToString(b)
+
+	if err := au.twoFactorRepo.Store(ctx, &domain.TwoFactor{Login: login, Secret: secret}); err != nil {
+		return "", "", err
+	}
+
+	issuer := au.twoFactorIssuer()
+
+	otpauthURL := fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s", url.PathEscape(issuer), url.PathEscape(login), secret, url.QueryEscape(issuer))
+
+	return secret, otpauthURL, nil
 }
 
-func (au *authUseCase) SignUp(ctx context.Context, a *domain.Auth, u *domain.User) (domain.Token, error) {
+func (au *authUseCase) VerifyTwoFactor(ctx context.Context, login string, code string) error {
+	twoFactor, err := au.twoFactorRepo.GetByLogin(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if twoFactor == nil {
+		return fmt.Errorf("two factor is not set up for login %s", login)
+	}
+
+	if !validTOTP(twoFactor.Secret, code, au.now()) {
+		return fmt.Errorf("%w for login %s", domain.ErrInvalidTwoFactor, login)
+	}
+
+	if !twoFactor.Enabled {
+		return au.twoFactorRepo.Enable(ctx, login)
+	}
+
+	return nil
+}
+
+func (au *authUseCase) SignUp(ctx context.Context, a *domain.Auth, u *domain.User, in domain.SignUpInput) (*domain.AuthResult, error) {
+	if au.conf.SignUpHoneypot && in.Honeypot != "" {
+		return au.newAuthResult(randomToken(), au.tokenTTL("")), nil
+	}
+
+	if au.conf.TermsVersion != "" && in.TermsVersion == "" {
+		return nil, fmt.Errorf("sign up for login %s requires accepting the terms", a.Login)
+	}
+
+	if au.conf.TermsVersion != "" && in.TermsVersion != au.conf.TermsVersion {
+		return nil, fmt.Errorf("terms version %s accepted by login %s is outdated", in.TermsVersion, a.Login)
+	}
+
+	if au.conf.InviteOnly && in.InviteToken == "" {
+		return nil, fmt.Errorf("sign up for login %s requires an invite", a.Login)
+	}
+
+	audience, err := au.clientAudience(in.Client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isValid, message := au.authValidator.ValidatePassword(ctx, a.Password); !isValid {
+		return nil, fmt.Errorf("%w: %s", domain.ErrWeakPassword, message)
+	}
+
 	auth, err := au.authRepo.GetByLogin(ctx, a.Login)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if auth != nil {
-		return "", fmt.Errorf("auth with login %s already exists", a.Login)
+		return nil, fmt.Errorf("auth with login %s already exists: %w", a.Login, domain.ErrLoginTaken)
 	}
 
 	user, err := au.userRepo.GetByEmail(ctx, u.Email)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if user != nil {
-		return "", fmt.Errorf("user with email %s already exists", u.Email)
+		return nil, fmt.Errorf("user with email %s already exists: %w", u.Email, domain.ErrEmailTaken)
+	}
+
+	if au.conf.UniquePhoneNumbers {
+		user, err := au.userRepo.GetByPhone(ctx, u.PhoneNumber)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user != nil {
+			return nil, fmt.Errorf("user with phone number %s already exists: %w", u.PhoneNumber, domain.ErrPhoneTaken)
+		}
 	}
 
-	a.Password = au.authService.EncodePass(ctx, a.Password)
+	if au.conf.InviteOnly {
+		inviteIsValid, err := au.codeService.CheckCode(ctx, &domain.Code{Value: in.InviteToken, Identifier: domain.InviteIdentifierPrefix + u.Email})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !inviteIsValid {
+			return nil, fmt.Errorf("invite %s is not valid for email %s", in.InviteToken, u.Email)
+		}
+	}
+
+	a.Role = au.conf.DefaultRole
+
+	if a.Role == "" {
+		a.Role = domain.RoleCustomer
+	}
+
+	if au.conf.FirstUserIsAdmin {
+		total, err := au.authRepo.Count(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if total == 0 {
+			a.Role = domain.RoleAdmin
+		}
+	}
+
+	if au.conf.TermsVersion != "" {
+		u.TermsVersion = in.TermsVersion
+		u.TermsAcceptedAt = au.now()
+	}
+
+	hash, err := au.authService.EncodePass(ctx, a.Password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	a.Password = hash
 
 	if err := au.authRepo.StoreWithUser(ctx, a, u); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if au.conf.InviteOnly {
+		// the invite is only used up once the account exists, a failed store
+		// leaves it for the retry; the email is now taken so a failure here
+		// can't be replayed
+		if _, err := au.codeService.ValidateCode(ctx, &domain.Code{Value: in.InviteToken, Identifier: domain.InviteIdentifierPrefix + u.Email}); err != nil {
+			log.Printf("Error trying to consume invite for email %s: %s", u.Email, err.Error())
+		}
+	}
+
+	if au.conf.WelcomeCreditAmount > 0 {
+		welcomeCredit := domain.CreditTransaction{UserUUID: u.UUID, Amount: au.conf.WelcomeCreditAmount, Reason: domain.CreditReasonWelcomeBonus, CreatedAt: au.now()}
+
+		// the account is already stored, failing the sign up here would make
+		// the client retry with a login that is now taken
+		if err := au.creditRepo.StoreTransaction(ctx, &welcomeCredit); err != nil {
+			log.Printf("Error trying to grant welcome credit to user %s: %s", u.UUID, err.Error())
+		}
+	}
+
+	if au.conf.EmailVerificationTTLMinutes > 0 {
+		// the account is already stored, failing to send the code must not
+		// turn the sign up into an error the client would retry
+		if err := au.sendEmailVerification(ctx, a.Login, u.Email); err != nil {
+			log.Printf("Error trying to send email verification for login %s: %s", a.Login, err.Error())
+		}
 	}
 
 	var tokenInfo domain.TokenInfo
 
 	tokenInfo.Info = a.Login
+	tokenInfo.Role = a.Role
+	tokenInfo.Audience = audience
+	tokenInfo.Verified = a.Verified
 
-	var thirtyDaysInMinutes int64 = 43200
+	tokenTTL := au.tokenTTL(a.Role)
 
-	token, err := au.tokenService.Sign(ctx, tokenInfo, thirtyDaysInMinutes)
+	token, err := au.tokenService.Sign(ctx, tokenInfo, tokenTTL)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	result := au.newAuthResult(token, tokenTTL)
+
+	if err := au.attachRefreshToken(ctx, result, a.Login, audience); err != nil {
+		return nil, err
 	}
 
-	return token, nil
+	return result, nil
 }
 
-func (au *authUseCase) ForgotPassCode(ctx context.Context, login string) error {
+func (au *authUseCase) ForgotPassCode(ctx context.Context, login string, channel string) error {
+	if channel == "" {
+		channel = domain.CodeChannelPhone
+	}
+
+	if channel != domain.CodeChannelPhone && channel != domain.CodeChannelEmail {
+		return fmt.Errorf("channel %s is not supported for forgot password codes", channel)
+	}
+
 	user, err := au.userRepo.GetByEmail(ctx, login)
 
 	if err != nil {
@@ -114,7 +652,17 @@ func (au *authUseCase) ForgotPassCode(ctx context.Context, login string) error {
 		return fmt.Errorf("user with login %s not found", login)
 	}
 
-	code, err := au.codeService.GenerateNewCode(ctx, login, 6, true, false)
+	lastIssuedAt, err := au.codeService.LastIssuedAt(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if !lastIssuedAt.IsZero() && au.now().Sub(lastIssuedAt) < au.resetCodeCooldown() {
+		return fmt.Errorf("forgot password code for login %s requested again before the cooldown: %w", login, domain.ErrTooManyRequests)
+	}
+
+	code, err := au.codeService.GenerateNewCode(ctx, login, channel, 6, true, false, au.resetCodeTTL())
 
 	if err != nil {
 		return err
@@ -124,10 +672,15 @@ func (au *authUseCase) ForgotPassCode(ctx context.Context, login string) error {
 
 	var messageConf domain.MessageConfig
 
-	messageConf.Medium = "phone"
+	messageConf.Medium = channel
 	messageConf.To = user.PhoneNumber
 	messageConf.Message = message
 
+	if channel == domain.CodeChannelEmail {
+		messageConf.To = user.Email
+		messageConf.Subject = "Recuperação de senha"
+	}
+
 	if errMessage := au.messageService.SendMessage(ctx, &messageConf); errMessage != nil {
 		return errMessage
 	}
@@ -135,40 +688,340 @@ func (au *authUseCase) ForgotPassCode(ctx context.Context, login string) error {
 	return nil
 }
 
-func (au *authUseCase) ForgotPassReset(ctx context.Context, code *domain.Code, newPass string) (domain.Token, error) {
+func (au *authUseCase) ForgotPassReset(ctx context.Context, code *domain.Code, newPass string, client string) (*domain.AuthResult, error) {
+	if !au.conf.ResetCodeSingleChannel {
+		code.Channel = ""
+	} else if code.Channel == "" {
+		code.Channel = domain.CodeChannelPhone
+	}
+
+	// checked before the code so a rejected password or client does not use it up
+	if isValid, message := au.authValidator.ValidatePassword(ctx, newPass); !isValid {
+		return nil, fmt.Errorf("%w: %s", domain.ErrWeakPassword, message)
+	}
+
+	audience, err := au.clientAudience(client)
+
+	if err != nil {
+		return nil, err
+	}
+
 	codeIsValid, err := au.codeService.ValidateCode(ctx, code)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !codeIsValid {
-		return "", fmt.Errorf("code %s with identifier %s is not valid", code.Value, code.Identifier)
+		result, err := au.retryForgotPassReset(ctx, code, newPass, audience)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			return result, nil
+		}
+
+		return nil, fmt.Errorf("code %s with identifier %s is not valid", code.Value, code.Identifier)
 	}
 
 	auth, err := au.authRepo.GetByLogin(ctx, code.Identifier)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	auth.Password = au.authService.EncodePass(ctx, newPass)
+	auth.Password, err = au.authService.EncodePass(ctx, newPass)
+
+	if err != nil {
+		return nil, err
+	}
 
 	if err = au.authRepo.Update(ctx, auth); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if err := au.purgeRefreshTokens(ctx, auth.Login); err != nil {
+		return nil, err
+	}
+
+	if au.conf.ResetRetryGraceSeconds > 0 {
+		// the password is already changed, failing to remember the code only
+		// costs the client its retry so the error is not surfaced
+		au.codeService.StoreConsumed(ctx, code)
+	}
+
+	return au.issueResetResult(ctx, auth, audience)
+}
+
+// retryForgotPassReset answers a repeated reset, sent with the same code and
+// new password inside the grace window, as if it was the first one. It never
+// changes the password again, so a used code with any other password is
+// rejected.
+func (au *authUseCase) retryForgotPassReset(ctx context.Context, code *domain.Code, newPass string, audience string) (*domain.AuthResult, error) {
+	if au.conf.ResetRetryGraceSeconds <= 0 {
+		return nil, nil
+	}
+
+	consumed, err := au.codeService.GetConsumed(ctx, code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if consumed == nil || au.now().Sub(consumed.ConsumedAt) > time.Duration(au.conf.ResetRetryGraceSeconds)*time.Second {
+		return nil, nil
+	}
+
+	auth, err := au.authRepo.GetByLogin(ctx, code.Identifier)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auth == nil || !au.authService.PassIsEqualHashedPass(ctx, newPass, auth.Password) {
+		return nil, nil
+	}
+
+	return au.issueResetResult(ctx, auth, audience)
+}
+
+// issueResetResult logs a login in after a password reset, asking for the TOTP
+// code first when it has two factor enabled.
+func (au *authUseCase) issueResetResult(ctx context.Context, auth *domain.Auth, audience string) (*domain.AuthResult, error) {
+	twoFactor, err := au.twoFactorEnabled(ctx, auth.Login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor {
+		return au.startTwoFactorLogin(ctx, auth.Login, audience)
 	}
 
 	var tokenInfo domain.TokenInfo
 
-	tokenInfo.Info = code.Identifier
+	tokenInfo.Info = auth.Login
+	tokenInfo.Role = auth.Role
+	tokenInfo.Audience = audience
+	tokenInfo.Verified = auth.Verified
+
+	tokenTTL := au.tokenTTL(auth.Role)
+
+	token, err := au.tokenService.Sign(ctx, tokenInfo, tokenTTL)
+
+	if err != nil {
+		return nil, err
+	}
 
-	var thirtyDaysInMinutes int64 = 43200
+	return au.newAuthResult(token, tokenTTL), nil
+}
 
-	token, err := au.tokenService.Sign(ctx, tokenInfo, thirtyDaysInMinutes)
+// VerifyToken decodes a token for the HTTP layer, a malformed, expired or
+// revoked token, or one issued for no configured client, gives ErrInvalidToken.
+func (au *authUseCase) VerifyToken(ctx context.Context, t domain.Token) (domain.TokenInfo, error) {
+	info, err := au.tokenService.GetInfo(ctx, t)
 
 	if err != nil {
-		return "", err
+		return domain.TokenInfo{}, fmt.Errorf("%w: %s", domain.ErrInvalidToken, err.Error())
+	}
+
+	if !au.audienceAllowed(info.Audience) {
+		return domain.TokenInfo{}, fmt.Errorf("%w: token for %s has audience %s of no configured client", domain.ErrInvalidToken, info.Info, info.Audience)
+	}
+
+	if info.ID != "" {
+		revoked, err := au.revokedRepo.Exists(ctx, info.ID)
+
+		if err != nil {
+			return domain.TokenInfo{}, err
+		}
+
+		if revoked {
+			return domain.TokenInfo{}, fmt.Errorf("%w: token for %s was revoked", domain.ErrInvalidToken, info.Info)
+		}
+	}
+
+	return *info, nil
+}
+
+// Logout revokes the token and deletes the refresh token issued with it. The
+// refresh cookie is only sent to /refresh, so a logout without the refresh
+// token ends every session of the login instead.
+func (au *authUseCase) Logout(ctx context.Context, t domain.Token, refresh domain.Token) error {
+	info, err := au.tokenService.GetInfo(ctx, t)
+
+	if err != nil {
+		return err
+	}
+
+	if info.ID == "" {
+		return fmt.Errorf("token for %s has no id and cannot be revoked", info.Info)
+	}
+
+	if err := au.revokedRepo.Store(ctx, &domain.RevokedToken{ID: info.ID, ExpiresAt: info.ExpiresAt}); err != nil {
+		return err
+	}
+
+	if au.conf.RefreshTokenTTLMinutes <= 0 {
+		return nil
+	}
+
+	if refresh == "" {
+		return au.refreshRepo.DeleteByLogin(ctx, info.Info)
+	}
+
+	stored, err := au.refreshRepo.GetByValue(ctx, string(refresh))
+
+	if err != nil {
+		return err
+	}
+
+	if stored == nil || stored.Login != info.Info {
+		return nil
+	}
+
+	return au.refreshRepo.DeleteByValue(ctx, stored.Value)
+}
+
+// purgeRefreshTokens ends the sessions kept alive by refresh tokens once the
+// password of a login changes.
+func (au *authUseCase) purgeRefreshTokens(ctx context.Context, login string) error {
+	if au.conf.RefreshTokenTTLMinutes <= 0 {
+		return nil
+	}
+
+	return au.refreshRepo.DeleteByLogin(ctx, login)
+}
+
+func (au *authUseCase) RefreshToken(ctx context.Context, refresh domain.Token) (*domain.AuthResult, error) {
+	stored, err := au.refreshRepo.GetByValue(ctx, string(refresh))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stored == nil {
+		return nil, fmt.Errorf("refresh token not found")
+	}
+
+	// the refresh token is single use, rotating it even when it is expired
+	// keeps stale ones from piling up
+	if err := au.refreshRepo.DeleteByValue(ctx, stored.Value); err != nil {
+		return nil, err
+	}
+
+	if au.now().After(stored.ExpiresAt) {
+		return nil, fmt.Errorf("refresh token for login %s is expired", stored.Login)
+	}
+
+	auth, err := au.authRepo.GetByLogin(ctx, stored.Login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auth == nil {
+		return nil, fmt.Errorf("auth with login %s not found", stored.Login)
+	}
+
+	var tokenInfo domain.TokenInfo
+
+	tokenInfo.Info = auth.Login
+	tokenInfo.Role = auth.Role
+	tokenInfo.Audience = stored.Audience
+	tokenInfo.Verified = auth.Verified
+
+	tokenTTL := au.tokenTTL(auth.Role)
+
+	token, err := au.tokenService.Sign(ctx, tokenInfo, tokenTTL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := au.newAuthResult(token, tokenTTL)
+
+	if err := au.attachRefreshToken(ctx, result, auth.Login, stored.Audience); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (au *authUseCase) VerifyEmail(ctx context.Context, login string, code string) error {
+	verification, err := au.verifyRepo.GetByLogin(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if verification == nil {
+		return fmt.Errorf("no pending email verification for login %s", login)
+	}
+
+	if au.now().After(verification.ExpiresAt) {
+		return fmt.Errorf("email verification code for login %s is expired", login)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(verification.Code), []byte(code)) != 1 {
+		return au.failEmailVerification(ctx, verification)
+	}
+
+	if err := au.authRepo.MarkVerified(ctx, login); err != nil {
+		return err
+	}
+
+	return au.verifyRepo.DeleteByLogin(ctx, login)
+}
+
+// failEmailVerification counts a wrong code and drops the pending verification
+// once maxEmailVerificationAttempts is reached so the code can not be guessed.
+func (au *authUseCase) failEmailVerification(ctx context.Context, verification *domain.EmailVerification) error {
+	if verification.Attempts+1 >= maxEmailVerificationAttempts {
+		if err := au.verifyRepo.DeleteByLogin(ctx, verification.Login); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%w: too many wrong email verification codes for login %s", domain.ErrTooManyRequests, verification.Login)
+	}
+
+	if err := au.verifyRepo.IncrementAttempts(ctx, verification.Login); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("wrong email verification code for login %s", verification.Login)
+}
+
+func (au *authUseCase) ChangePassword(ctx context.Context, login string, oldPassword string, newPassword string) error {
+	auth, err := au.authRepo.GetByLogin(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if auth == nil || !au.authService.PassIsEqualHashedPass(ctx, oldPassword, auth.Password) {
+		return fmt.Errorf("wrong current password for login %s: %w", login, domain.ErrInvalidCredentials)
+	}
+
+	if newPassword == oldPassword {
+		return domain.ErrPasswordUnchanged
+	}
+
+	if isValid, message := au.authValidator.ValidatePassword(ctx, newPassword); !isValid {
+		return fmt.Errorf("%w: %s", domain.ErrWeakPassword, message)
+	}
+
+	hash, err := au.authService.EncodePass(ctx, newPassword)
+
+	if err != nil {
+		return err
+	}
+
+	if err := au.authRepo.UpdatePassword(ctx, login, hash); err != nil {
+		return err
 	}
 
-	return token, nil
+	return au.purgeRefreshTokens(ctx, login)
 }
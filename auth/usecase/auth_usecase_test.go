@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
@@ -11,6 +12,14 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// acceptingAuthValidator takes any password, for the tests that are not about
+// the password policy.
+func acceptingAuthValidator() *mocks.MockAuthValidator {
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockAuthValidator.On("ValidatePassword", mock.Anything, mock.Anything).Return(true, "")
+	return mockAuthValidator
+}
+
 func TestLoginCheckLoginExistsError(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
 
@@ -19,26 +28,37 @@ func TestLoginCheckLoginExistsError(t *testing.T) {
 
 	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, errors.New("error message"))
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	_, err := authUseCase.Login(context.Background(), &mockAuth)
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
+	assert.False(t, errors.Is(err, domain.ErrInvalidCredentials))
 }
 
 func TestLoginCheckLoginExists(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
 
 	var mockAuth domain.Auth
 	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
 	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil)
+	mockAuthService.On("EncodePass", mock.Anything, mock.Anything).Return("dummy hash", nil).Once()
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "dummy hash").Return(false)
 
-	_, err := authUseCase.Login(context.Background(), &mockAuth)
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	assert.Error(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+		assert.True(t, errors.Is(err, domain.ErrInvalidCredentials))
+	}
+
+	mockAuthService.AssertNumberOfCalls(t, "EncodePass", 1)
+	mockAuthService.AssertNumberOfCalls(t, "PassIsEqualHashedPass", 2)
 }
 
 func TestLoginPassIsEqualHashedPassError(t *testing.T) {
@@ -50,15 +70,15 @@ func TestLoginPassIsEqualHashedPassError(t *testing.T) {
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "invalid password"
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", domain.RoleCustomer, false, nil)
 
 	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "valid password").Return(false)
 
-	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	_, err := authUseCase.Login(context.Background(), &mockAuth)
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
-	assert.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidCredentials))
 }
 
 func TestLoginSignTokenError(t *testing.T) {
@@ -70,19 +90,20 @@ func TestLoginSignTokenError(t *testing.T) {
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
 	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
 	var thirtyDaysInMinutes int64 = 43200
 
-	tokenInfo := domain.TokenInfo{Info: mockAuth.Login}
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
 	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("", errors.New("error message"))
 
-	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	_, err := authUseCase.Login(context.Background(), &mockAuth)
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
 }
@@ -96,446 +117,2811 @@ func TestLoginSuccess(t *testing.T) {
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
 	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
 	var thirtyDaysInMinutes int64 = 43200
 
-	tokenInfo := domain.TokenInfo{Info: mockAuth.Login}
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
 	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	token, err := authUseCase.Login(context.Background(), &mockAuth)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Nil(t, err)
-	assert.Equal(t, token, domain.Token("valid token"))
+	assert.Equal(t, result.Token, domain.Token("valid token"))
+	assert.Equal(t, domain.TokenTransportBearer, result.Transport)
+	assert.Equal(t, int64(0), result.MaxAge)
 }
 
-func TestSignUpCheckLoginExistsError(t *testing.T) {
+func TestLoginRehashesLowerCostPassword(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	var mockAuth domain.Auth
-	mockAuth.Login = "valid login"
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, errors.New("error message"))
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "old hash", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("UpdatePassword", mock.Anything, mockAuth.Login, "new hash").Return(nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "old hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "old hash").Return(true)
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("new hash", nil)
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, nil)
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
 
-	assert.Error(t, err)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockAuthRepo.AssertExpectations(t)
 }
 
-func TestSignUpLoginAlreadyExists(t *testing.T) {
+func TestLoginKeepsCurrentCostPassword(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	var mockAuth domain.Auth
-	mockAuth.Login = "valid login"
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "current hash", domain.RoleCustomer, false, nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "current hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "current hash").Return(false)
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, nil)
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
 
-	assert.Error(t, err)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.Nil(t, err)
+	mockAuthService.AssertNotCalled(t, "EncodePass", mock.Anything, mock.Anything)
+	mockAuthRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestSignUpCheckUserExistsError(t *testing.T) {
+func TestLoginRehashErrorStillLogsIn(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockUserRepo := new(mocks.MockUserRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	var mockAuth domain.Auth
-	mockAuth.Login = "valid login"
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
 
-	var mockUser domain.User
-	mockUser.Email = "valid email"
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "old hash", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("UpdatePassword", mock.Anything, mockAuth.Login, "new hash").Return(errors.New("error message"))
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "old hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "old hash").Return(true)
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("new hash", nil)
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, errors.New("error message"))
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
-	assert.Error(t, err)
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
 }
 
-func TestSignUpCheckUserExists(t *testing.T) {
+func TestLoginRehashEncodeErrorKeepsOldHash(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockUserRepo := new(mocks.MockUserRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	var mockAuth domain.Auth
-	mockAuth.Login = "valid login"
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
 
-	var mockUser domain.User
-	mockUser.Email = "valid email"
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "old hash", domain.RoleCustomer, false, nil)
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "old hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "old hash").Return(true)
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("", errors.New("error message"))
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
-	assert.Error(t, err)
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockAuthRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestSignUpStoreUserError(t *testing.T) {
+func TestLoginKnownClientAudience(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockUserRepo := new(mocks.MockUserRepository)
 	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
 	var mockAuth domain.Auth
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	var mockUser domain.User
-	mockUser.Email = "user email"
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
-	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password")
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer, Audience: "e-commerce-mobile"}
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", nil)
-	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password"}, &mockUser).Return(errors.New("error message"))
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo)
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web", "mobile": "e-commerce-mobile"}}
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, conf)
 
-	assert.Error(t, err)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{Client: "mobile"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTokenService.AssertExpectations(t)
 }
 
-func TestSignUpSignTokenError(t *testing.T) {
+func TestLoginUnknownClient(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockTokenService := new(mocks.MockTokenService)
-	mockAuthService := new(mocks.MockAuthService)
 
 	var mockAuth domain.Auth
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	var mockUser domain.User
-	mockUser.Email = "user email"
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
 
-	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password")
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, conf)
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{Client: "desktop"})
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", nil)
-	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password"}, &mockUser).Return(nil)
+	assert.ErrorIs(t, err, domain.ErrUnknownClient)
+	mockAuthRepo.AssertNotCalled(t, "GetByLogin", mock.Anything, mock.Anything)
+}
 
-	var thirtyDaysInMinutes int64 = 43200
+func TestLoginConfiguredTokenTTL(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	tokenInfo := domain.TokenInfo{Info: mockAuth.Login}
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
-	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("", errors.New("error message"))
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo)
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
-	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser)
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(60)).Return("valid token", nil)
 
-	assert.Error(t, err)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{TokenTTLMinutes: 60})
+
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTokenService.AssertExpectations(t)
 }
 
-func TestSignUpSuccess(t *testing.T) {
+func TestLoginIssuesRefreshToken(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockTokenService := new(mocks.MockTokenService)
 	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
 
 	var mockAuth domain.Auth
 	mockAuth.Login = "valid login"
 	mockAuth.Password = "valid password"
 
-	var mockUser domain.User
-	mockUser.Email = "user email"
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
 
-	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password")
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
-	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password"}, &mockUser).Return(nil)
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(15)).Return("valid token", nil)
 
-	var thirtyDaysInMinutes int64 = 43200
+	mockRefreshTokenRepo.On("Store", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+		return rt.Value != "" && rt.Login == mockAuth.Login && rt.ExpiresAt.Equal(now.Add(60*time.Minute))
+	})).Return(nil)
 
-	tokenInfo := domain.TokenInfo{Info: mockAuth.Login}
+	uc := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{TokenTTLMinutes: 15, RefreshTokenTTLMinutes: 60}).(*authUseCase)
+	uc.now = func() time.Time { return now }
 
-	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+	result, err := uc.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestLoginRefreshTokenCookieMaxAge(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo)
+	mockTokenService.On("Sign", mock.Anything, mock.Anything, int64(15)).Return("valid token", nil)
 
-	token, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser)
+	mockRefreshTokenRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	conf := domain.AuthConfig{TokenTransport: domain.TokenTransportCookie, TokenTTLMinutes: 15, RefreshTokenTTLMinutes: 43200}
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, conf)
+
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Nil(t, err)
-	assert.Equal(t, token, domain.Token("valid token"))
+	assert.Equal(t, int64(900), result.MaxAge)
+	assert.Equal(t, int64(2592000), result.RefreshMaxAge)
 }
 
-func TestForgotPassCodeGetUserByLoginError(t *testing.T) {
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockCodeService := new(mocks.MockCodeService)
-	mockMessageService := new(mocks.MockMessageService)
+func TestLoginStoreRefreshTokenError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
 
-	mockLogin := "valid login"
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(nil, errors.New("error message"))
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
+
+	mockTokenService.On("Sign", mock.Anything, mock.Anything, int64(43200)).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo)
+	mockRefreshTokenRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("error message"))
 
-	err := authUseCase.ForgotPassCode(context.Background(), mockLogin)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
 }
 
-func TestForgotPassCodeNoUserFound(t *testing.T) {
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockCodeService := new(mocks.MockCodeService)
-	mockMessageService := new(mocks.MockMessageService)
+func TestLoginVerifiedFlag(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	mockLogin := "valid login"
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(nil, nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, true, nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	err := authUseCase.ForgotPassCode(context.Background(), mockLogin)
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer, Verified: true}
 
-	assert.Error(t, err)
-}
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
 
-func TestForgotPassCodeSendMessageError(t *testing.T) {
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockCodeService := new(mocks.MockCodeService)
-	mockMessageService := new(mocks.MockMessageService)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
 
-	mockLogin := "valid login"
+	_, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+	assert.Nil(t, err)
+	mockTokenService.AssertExpectations(t)
+}
+
+func TestLoginRoleTTLSetsTokenAndCookieLifetime(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, int8(6), true, false).Return("generated code", mockLogin, nil)
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
 
-	var messageConf domain.MessageConfig
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "hash", domain.RoleAdmin, false, nil)
 
-	messageConf.Medium = "phone"
-	messageConf.To = "user phone number"
-	messageConf.Message = "O código para recuperar sua senha é generated code"
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "hash").Return(false)
 
-	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(errors.New("error message"))
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleAdmin}, int64(10)).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo)
+	conf := domain.AuthConfig{TokenTransport: domain.TokenTransportCookie, TokenTTLMinutes: 15, RoleTTLMinutes: map[string]int64{domain.RoleAdmin: 10}}
 
-	err := authUseCase.ForgotPassCode(context.Background(), mockLogin)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, conf)
 
-	assert.Error(t, err)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(600), result.MaxAge)
+	mockTokenService.AssertExpectations(t)
 }
 
-func TestForgotPassCodeSuccess(t *testing.T) {
-	mockUserRepo := new(mocks.MockUserRepository)
-	mockCodeService := new(mocks.MockCodeService)
-	mockMessageService := new(mocks.MockMessageService)
+func TestLoginSuccessCookieTransport(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
 
-	mockLogin := "valid login"
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, mockAuth.Password, domain.RoleCustomer, false, nil)
 
-	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, int8(6), true, false).Return("generated code", mockLogin, nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, mockAuth.Password).Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, mockAuth.Password).Return(false)
 
-	var messageConf domain.MessageConfig
+	var thirtyDaysInMinutes int64 = 43200
 
-	messageConf.Medium = "phone"
-	messageConf.To = "user phone number"
-	messageConf.Message = "O código para recuperar sua senha é generated code"
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
-	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(nil)
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{TokenTransport: domain.TokenTransportCookie})
 
-	err := authUseCase.ForgotPassCode(context.Background(), mockLogin)
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Nil(t, err)
+	assert.Equal(t, result.Token, domain.Token("valid token"))
+	assert.Equal(t, domain.TokenTransportCookie, result.Transport)
+	assert.Equal(t, thirtyDaysInMinutes*60, result.MaxAge)
 }
 
-func TestForgotPassResetValidateCodeError(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
+func TestLoginMinDurationNotFound(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
 
-	var mockCode domain.Code
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
 
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	clock := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept time.Duration
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, errors.New("error message"))
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil).Run(func(args mock.Arguments) {
+		clock = clock.Add(20 * time.Millisecond)
+	})
+
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthService.On("EncodePass", mock.Anything, mock.Anything).Return("dummy hash", nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mock.Anything, "dummy hash").Return(false)
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil)
+	uc := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{LoginMinDurationMillis: 500}).(*authUseCase)
+	uc.now = func() time.Time { return clock }
+	uc.sleep = func(d time.Duration) { slept += d }
 
-	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass")
+	_, err := uc.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
+	assert.Equal(t, 480*time.Millisecond, slept)
 }
 
-func TestForgotPassResetCodeInvalid(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
+func TestLoginMinDurationWrongPassword(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
 
-	var mockCode domain.Code
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "invalid password"
 
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	clock := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept time.Duration
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "valid password").Return(false).Run(func(args mock.Arguments) {
+		clock = clock.Add(300 * time.Millisecond)
+	})
 
-	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil)
+	uc := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{LoginMinDurationMillis: 500}).(*authUseCase)
+	uc.now = func() time.Time { return clock }
+	uc.sleep = func(d time.Duration) { slept += d }
 
-	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass")
+	_, err := uc.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
+	assert.Equal(t, 200*time.Millisecond, slept)
 }
 
-func TestForgotPassResetGetAuthByLoginError(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
-	mockAuthService := new(mocks.MockAuthService)
+func TestLoginMinDurationAlreadyElapsed(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
 
-	var mockCode domain.Code
-
-	mockNewPass := "new pass"
-	mockEncodedNewPass := "encoded new pass"
-
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+	clock := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept time.Duration
 
-	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil).Run(func(args mock.Arguments) {
+		clock = clock.Add(time.Second)
+	})
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(nil, errors.New("error message"))
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthService.On("EncodePass", mock.Anything, mock.Anything).Return("dummy hash", nil)
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mock.Anything, "dummy hash").Return(false)
 
-	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, nil)
+	uc := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{LoginMinDurationMillis: 500}).(*authUseCase)
+	uc.now = func() time.Time { return clock }
+	uc.sleep = func(d time.Duration) { slept += d }
 
-	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass)
+	_, err := uc.Login(context.Background(), &mockAuth, domain.LoginInput{})
 
 	assert.Error(t, err)
+	assert.Equal(t, time.Duration(0), slept)
 }
 
-func TestForgotPassResetUpdateAuthError(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
+func TestSignUpRejectedByPasswordPolicy(t *testing.T) {
 	mockAuthService := new(mocks.MockAuthService)
 	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthValidator := new(mocks.MockAuthValidator)
 
-	var mockCode domain.Code
+	mockAuth := domain.Auth{Login: "valid login", Password: "a"}
 
-	mockNewPass := "new pass"
-	mockEncodedNewPass := "encoded new pass"
+	mockAuthValidator.On("ValidatePassword", mock.Anything, "a").Return(false, "password need to have at least 3 characters")
 
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, mockAuthValidator, domain.AuthConfig{})
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &domain.User{Email: "valid email"}, domain.SignUpInput{})
 
-	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass)
+	assert.ErrorIs(t, err, domain.ErrWeakPassword)
+	assert.Contains(t, err.Error(), "password need to have at least 3 characters")
+	mockAuthService.AssertNotCalled(t, "EncodePass", mock.Anything, mock.Anything)
+	mockAuthRepo.AssertNotCalled(t, "StoreWithUser", mock.Anything, mock.Anything, mock.Anything)
+}
 
-	var auth domain.Auth
+func TestSignUpCheckLoginExistsError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
 
-	auth.ID = 1
-	auth.UUID = "uuid"
-	auth.Login = mockCode.Identifier
-	auth.Password = mockEncodedNewPass
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", nil)
-	mockAuthRepo.On("Update", mock.Anything, &auth).Return(errors.New("error message"))
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, errors.New("error message"))
 
-	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
 
-	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass)
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, nil, domain.SignUpInput{})
 
 	assert.Error(t, err)
 }
 
-func TestForgotPassResetSignTokenError(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
-	mockAuthService := new(mocks.MockAuthService)
+func TestSignUpLoginAlreadyExists(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
-	mockTokenService := new(mocks.MockTokenService)
 
-	var mockCode domain.Code
-
-	mockNewPass := "new pass"
-	mockEncodedNewPass := "encoded new pass"
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
 
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", domain.RoleCustomer, false, nil)
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
 
-	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass)
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, nil, domain.SignUpInput{})
 
-	var auth domain.Auth
+	assert.True(t, errors.Is(err, domain.ErrLoginTaken))
+}
 
-	auth.ID = 1
-	auth.UUID = "uuid"
-	auth.Login = mockCode.Identifier
-	auth.Password = mockEncodedNewPass
+func TestSignUpCheckUserExistsError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", nil)
-	mockAuthRepo.On("Update", mock.Anything, &auth).Return(nil)
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "valid email"
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Error(t, err)
+}
+
+func TestSignUpCheckUserExists(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "valid email"
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.True(t, errors.Is(err, domain.ErrEmailTaken))
+}
+
+func TestSignUpPhoneTaken(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockAuth := domain.Auth{Login: "valid login"}
+	mockUser := domain.User{Email: "valid email", PhoneNumber: "(11) 98888-8888"}
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+	mockUserRepo.On("GetByPhone", mock.Anything, mockUser.PhoneNumber).Return(1, "uuid", "other email", "first name", "last name", mockUser.PhoneNumber, "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{UniquePhoneNumbers: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.True(t, errors.Is(err, domain.ErrPhoneTaken))
+	mockAuthRepo.AssertNotCalled(t, "StoreWithUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignUpDuplicatePhoneAllowedWhenNotUnique(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
+	mockUser := domain.User{Email: "user email", PhoneNumber: "(11) 98888-8888"}
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, &mockUser).Return(nil)
+	mockTokenService.On("Sign", mock.Anything, mock.Anything, mock.Anything).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "GetByPhone", mock.Anything, mock.Anything)
+}
+
+func TestSignUpStoreUserError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Error(t, err)
+}
+
+func TestSignUpSignTokenError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", "valid login", "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
 
 	var thirtyDaysInMinutes int64 = 43200
 
-	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier}
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
 	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("", errors.New("error message"))
 
-	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
 
-	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass)
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
 
 	assert.Error(t, err)
 }
 
-func TestForgotPassResetSuccess(t *testing.T) {
-	mockCodeService := new(mocks.MockCodeService)
+func TestSignUpSuccess(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, result.Token, domain.Token("valid token"))
+	assert.Equal(t, domain.TokenTransportBearer, result.Transport)
+}
+
+func TestSignUpClientAudience(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
 	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer, Audience: "e-commerce-mobile"}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web", "mobile": "e-commerce-mobile"}}
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), conf)
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{Client: "mobile"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTokenService.AssertExpectations(t)
+}
+
+func TestSignUpUnknownClient(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), conf)
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &domain.User{}, domain.SignUpInput{Client: "desktop"})
+
+	assert.ErrorIs(t, err, domain.ErrUnknownClient)
+	mockAuthRepo.AssertNotCalled(t, "StoreWithUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignUpConfiguredTokenTTL(t *testing.T) {
 	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
 	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
 
-	var mockCode domain.Code
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
 
-	mockNewPass := "new pass"
-	mockEncodedNewPass := "encoded new pass"
+	var mockUser domain.User
+	mockUser.Email = "user email"
 
-	mockCode.Identifier = "identifier"
-	mockCode.Value = "Value"
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
 
-	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
 
-	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass)
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
 
-	var auth domain.Auth
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
 
-	auth.ID = 1
-	auth.UUID = "uuid"
-	auth.Login = mockCode.Identifier
-	auth.Password = mockEncodedNewPass
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(60)).Return("valid token", nil)
 
-	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", nil)
-	mockAuthRepo.On("Update", mock.Anything, &auth).Return(nil)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{TokenTTLMinutes: 60})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTokenService.AssertExpectations(t)
+}
+
+func TestSignUpSuccessCookieTransport(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{TokenTransport: domain.TokenTransportCookie})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, result.Token, domain.Token("valid token"))
+	assert.Equal(t, domain.TokenTransportCookie, result.Transport)
+	assert.Equal(t, thirtyDaysInMinutes*60, result.MaxAge)
+}
+
+func TestSignUpConfiguredDefaultRole(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: "member"}, &mockUser).Return(nil)
 
 	var thirtyDaysInMinutes int64 = 43200
 
-	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier}
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: "member"}
 
 	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
 
-	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil)
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{DefaultRole: "member"})
 
-	token, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass)
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
 
 	assert.Nil(t, err)
-	assert.Equal(t, token, domain.Token("valid token"))
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestSignUpFirstUserIsAdmin(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("Count", mock.Anything).Return(0, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleAdmin}, &mockUser).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleAdmin}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{FirstUserIsAdmin: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestSignUpFirstUserIsAdminNotFirstUser(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("Count", mock.Anything).Return(3, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{FirstUserIsAdmin: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestSignUpCountAuthError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("Count", mock.Anything).Return(0, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{FirstUserIsAdmin: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Error(t, err)
+}
+
+func TestSignUpInviteOnlyWithoutInvite(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{InviteOnly: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "GetByLogin", mock.Anything, mock.Anything)
+}
+
+func TestSignUpInviteOnlyInvalidInvite(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockCodeService.On("CheckCode", mock.Anything, &domain.Code{Value: "invite", Identifier: domain.InviteIdentifierPrefix + mockUser.Email}).Return(false, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{InviteOnly: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{InviteToken: "invite"})
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "StoreWithUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignUpInviteOnlyWithValidInvite(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockCodeService.On("CheckCode", mock.Anything, &domain.Code{Value: "invite", Identifier: domain.InviteIdentifierPrefix + mockUser.Email}).Return(true, nil)
+	mockCodeService.On("ValidateCode", mock.Anything, &domain.Code{Value: "invite", Identifier: domain.InviteIdentifierPrefix + mockUser.Email}).Return(true, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{InviteOnly: true})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{InviteToken: "invite"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockCodeService.AssertCalled(t, "ValidateCode", mock.Anything, &domain.Code{Value: "invite", Identifier: domain.InviteIdentifierPrefix + mockUser.Email})
+}
+
+func TestSignUpInviteOnlyStoreErrorKeepsInvite(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockCodeService.On("CheckCode", mock.Anything, &domain.Code{Value: "invite", Identifier: domain.InviteIdentifierPrefix + mockUser.Email}).Return(true, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, &mockUser).Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{InviteOnly: true})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{InviteToken: "invite"})
+
+	assert.Error(t, err)
+	mockCodeService.AssertNotCalled(t, "ValidateCode", mock.Anything, mock.Anything)
+}
+
+func TestSignUpWelcomeCredit(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCreditRepo := new(mocks.MockCreditRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.UUID = "user uuid"
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	mockCreditRepo.On("StoreTransaction", mock.Anything, mock.MatchedBy(func(t *domain.CreditTransaction) bool {
+		return t.UserUUID == "user uuid" && t.Amount == 1000 && t.Reason == domain.CreditReasonWelcomeBonus && !t.CreatedAt.IsZero()
+	})).Return(nil)
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, mockCreditRepo, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{WelcomeCreditAmount: 1000})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockCreditRepo.AssertExpectations(t)
+}
+
+func TestSignUpWelcomeCreditStoreErrorStillSignsUp(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCreditRepo := new(mocks.MockCreditRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, &mockUser).Return(nil)
+
+	mockCreditRepo.On("StoreTransaction", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, mockCreditRepo, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{WelcomeCreditAmount: 1000})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockCreditRepo.AssertExpectations(t)
+}
+
+func TestSignUpWithoutWelcomeCredit(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCreditRepo := new(mocks.MockCreditRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, &mockUser).Return(nil)
+
+	mockTokenService.On("Sign", mock.Anything, mock.Anything, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, mockCreditRepo, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{WelcomeCreditAmount: 0})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	mockCreditRepo.AssertNotCalled(t, "StoreTransaction", mock.Anything, mock.Anything)
+}
+
+func TestSignUpHoneypotFilled(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{SignUpHoneypot: true})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{Honeypot: "http://spam.example"})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, result.Token)
+	mockAuthRepo.AssertNotCalled(t, "StoreWithUser", mock.Anything, mock.Anything, mock.Anything)
+	mockTokenService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignUpHoneypotEmpty(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	tokenInfo := domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{SignUpHoneypot: true})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestSignUpTermsNotAccepted(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{TermsVersion: "2022-05"})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "GetByLogin", mock.Anything, mock.Anything)
+}
+
+func TestSignUpTermsOutdated(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{TermsVersion: "2022-05"})
+
+	_, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{TermsVersion: "2021-01"})
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "GetByLogin", mock.Anything, mock.Anything)
+}
+
+func TestSignUpTermsAcceptanceRecorded(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	acceptedAt := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.TermsVersion == "2022-05" && u.TermsAcceptedAt.Equal(acceptedAt)
+	})).Return(nil)
+
+	mockTokenService.On("Sign", mock.Anything, mock.Anything, int64(43200)).Return("valid token", nil)
+
+	uc := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{TermsVersion: "2022-05"}).(*authUseCase)
+	uc.now = func() time.Time { return acceptedAt }
+
+	result, err := uc.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{TermsVersion: "2022-05"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestSignUpSendsEmailVerification(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockMessageService := new(mocks.MockMessageService)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, &domain.Auth{Login: mockAuth.Login, Password: "hashed password", Role: domain.RoleCustomer}, &mockUser).Return(nil)
+
+	var code string
+
+	mockVerificationRepo.On("Store", mock.Anything, mock.MatchedBy(func(ev *domain.EmailVerification) bool {
+		code = ev.Code
+		return ev.Login == mockAuth.Login && len(ev.Code) == 6 && ev.ExpiresAt.Equal(now.Add(24*time.Hour))
+	})).Return(nil)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(mc *domain.MessageConfig) bool {
+		return mc.Medium == "email" && mc.To == mockUser.Email && mc.Message == "O código para confirmar seu email é "+code
+	})).Return(nil)
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
+
+	uc := NewAuthUseCase(mockAuthService, mockTokenService, nil, mockMessageService, mockAuthRepo, mockUserRepo, nil, nil, nil, mockVerificationRepo, nil, acceptingAuthValidator(), domain.AuthConfig{EmailVerificationTTLMinutes: 1440}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	result, err := uc.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockVerificationRepo.AssertExpectations(t)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestSignUpEmailVerificationErrorStillSignsUp(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	var mockAuth domain.Auth
+	mockAuth.Login = "valid login"
+	mockAuth.Password = "valid password"
+
+	var mockUser domain.User
+	mockUser.Email = "user email"
+
+	mockAuthService.On("EncodePass", mock.Anything, mockAuth.Password).Return("hashed password", nil)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockUser.Email).Return(nil, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(nil, nil)
+	mockAuthRepo.On("StoreWithUser", mock.Anything, mock.Anything, &mockUser).Return(nil)
+
+	mockVerificationRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, mockUserRepo, nil, nil, nil, mockVerificationRepo, nil, acceptingAuthValidator(), domain.AuthConfig{EmailVerificationTTLMinutes: 1440})
+
+	result, err := authUseCase.SignUp(context.Background(), &mockAuth, &mockUser, domain.SignUpInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+}
+func TestForgotPassCodeGetUserByLoginError(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockLogin := "valid login"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassCodeNoUserFound(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockLogin := "valid login"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(nil, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassCodeSendMessageError(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockLogin := "valid login"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Time{}, nil)
+	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, domain.CodeChannelPhone, int8(6), true, false, 30*time.Minute).Return("generated code", mockLogin, domain.CodeChannelPhone, nil)
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "phone"
+	messageConf.To = "user phone number"
+	messageConf.Message = "O código para recuperar sua senha é generated code"
+
+	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassCodeSuccess(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockLogin := "valid login"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Time{}, nil)
+	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, domain.CodeChannelPhone, int8(6), true, false, 30*time.Minute).Return("generated code", mockLogin, domain.CodeChannelPhone, nil)
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "phone"
+	messageConf.To = "user phone number"
+	messageConf.Message = "O código para recuperar sua senha é generated code"
+
+	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.Nil(t, err)
+}
+
+func TestForgotPassResetRejectedByPasswordPolicy(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockAuthValidator.On("ValidatePassword", mock.Anything, "a").Return(false, "password need to have at least 3 characters")
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, mockAuthValidator, domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "a", "")
+
+	assert.ErrorIs(t, err, domain.ErrWeakPassword)
+	mockCodeService.AssertNotCalled(t, "ValidateCode", mock.Anything, mock.Anything)
+	mockAuthService.AssertNotCalled(t, "EncodePass", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetValidateCodeError(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockCode domain.Code
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassResetCodeExpired(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockCode domain.Code
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, domain.ErrCodeExpired)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.ErrorIs(t, err, domain.ErrCodeExpired)
+	mockAuthRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetCodeInvalid(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockCode domain.Code
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassCodeUsesConfiguredTTL(t *testing.T) {
+	mockLogin := "valid login"
+
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", mockLogin, "first name", "last name", "phone number", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Time{}, nil)
+	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, domain.CodeChannelPhone, int8(6), true, false, 10*time.Minute).Return("generated code", mockLogin, domain.CodeChannelPhone, nil)
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{ResetCodeTTLMinutes: 10})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.NoError(t, err)
+	mockCodeService.AssertExpectations(t)
+}
+
+func TestForgotPassCodeWithinCooldown(t *testing.T) {
+	mockLogin := "valid login"
+
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", mockLogin, "first name", "last name", "phone number", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Now().Add(-30*time.Second), nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.ErrorIs(t, err, domain.ErrTooManyRequests)
+	mockCodeService.AssertNotCalled(t, "GenerateNewCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassCodeAfterCooldown(t *testing.T) {
+	mockLogin := "valid login"
+
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", mockLogin, "first name", "last name", "phone number", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Now().Add(-30*time.Second), nil)
+	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, domain.CodeChannelPhone, int8(6), true, false, 30*time.Minute).Return("generated code", mockLogin, domain.CodeChannelPhone, nil)
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{ResetCodeCooldownSeconds: 20})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.NoError(t, err)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestForgotPassCodeLastIssuedAtError(t *testing.T) {
+	mockLogin := "valid login"
+
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", mockLogin, "first name", "last name", "phone number", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Time{}, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, "")
+
+	assert.Error(t, err)
+	mockCodeService.AssertNotCalled(t, "GenerateNewCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetGetAuthByLoginError(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+	mockEncodedNewPass := "encoded new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassResetUpdateAuthError(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+	mockEncodedNewPass := "encoded new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass, nil)
+
+	var auth domain.Auth
+
+	auth.ID = 1
+	auth.UUID = "uuid"
+	auth.Login = mockCode.Identifier
+	auth.Password = mockEncodedNewPass
+	auth.Role = domain.RoleCustomer
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("Update", mock.Anything, &auth).Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassResetSignTokenError(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+	mockEncodedNewPass := "encoded new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass, nil)
+
+	var auth domain.Auth
+
+	auth.ID = 1
+	auth.UUID = "uuid"
+	auth.Login = mockCode.Identifier
+	auth.Password = mockEncodedNewPass
+	auth.Role = domain.RoleCustomer
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("Update", mock.Anything, &auth).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("", errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassResetSuccess(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+	mockEncodedNewPass := "encoded new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass, nil)
+
+	var auth domain.Auth
+
+	auth.ID = 1
+	auth.UUID = "uuid"
+	auth.Login = mockCode.Identifier
+	auth.Password = mockEncodedNewPass
+	auth.Role = domain.RoleCustomer
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("Update", mock.Anything, &auth).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, result.Token, domain.Token("valid token"))
+}
+
+func TestForgotPassResetClientAudience(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, "new pass").Return("encoded new pass", nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(1, "uuid", mockCode.Identifier, "valid password", domain.RoleCustomer, true, nil)
+	mockAuthRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier, Role: domain.RoleCustomer, Audience: "e-commerce-web", Verified: true}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(43200)).Return("valid token", nil)
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), conf)
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "web")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTokenService.AssertExpectations(t)
+}
+
+func TestForgotPassResetUnknownClientKeepsCode(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), conf)
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "desktop")
+
+	assert.ErrorIs(t, err, domain.ErrUnknownClient)
+	mockCodeService.AssertNotCalled(t, "ValidateCode", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetPurgesRefreshTokens(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockSuccessfulForgotPassReset(mockCodeService, mockAuthService, mockAuthRepo, mockTokenService, &mockCode, "new pass")
+
+	mockRefreshTokenRepo.On("DeleteByLogin", mock.Anything, mockCode.Identifier).Return(nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, acceptingAuthValidator(), domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestForgotPassResetStoresConsumedCode(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+	mockEncodedNewPass := "encoded new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(true, nil)
+	mockCodeService.On("StoreConsumed", mock.Anything, &mockCode).Return(nil)
+
+	mockAuthService.On("EncodePass", mock.Anything, mockNewPass).Return(mockEncodedNewPass, nil)
+
+	var auth domain.Auth
+
+	auth.ID = 1
+	auth.UUID = "uuid"
+	auth.Login = mockCode.Identifier
+	auth.Password = mockEncodedNewPass
+	auth.Role = domain.RoleCustomer
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, auth.Login).Return(1, "uuid", auth.Login, "valid password", domain.RoleCustomer, false, nil)
+	mockAuthRepo.On("Update", mock.Anything, &auth).Return(nil)
+
+	var thirtyDaysInMinutes int64 = 43200
+
+	tokenInfo := domain.TokenInfo{Info: mockCode.Identifier, Role: domain.RoleCustomer}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, thirtyDaysInMinutes).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60})
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockCodeService.AssertExpectations(t)
+}
+
+func mockSuccessfulForgotPassReset(codeService *mocks.MockCodeService, authService *mocks.MockAuthService, authRepo *mocks.MockAuthRepository, tokenService *mocks.MockTokenService, code *domain.Code, newPass string) {
+	codeService.On("ValidateCode", mock.Anything, code).Return(true, nil).Once()
+	codeService.On("ValidateCode", mock.Anything, code).Return(false, nil)
+
+	authService.On("EncodePass", mock.Anything, newPass).Return("encoded "+newPass, nil)
+
+	authRepo.On("GetByLogin", mock.Anything, code.Identifier).Return(1, "uuid", code.Identifier, "encoded "+newPass, domain.RoleCustomer, false, nil)
+	authRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	tokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: code.Identifier, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
+}
+
+func TestForgotPassResetUsedCodeRejectedForDifferentPassword(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockSuccessfulForgotPassReset(mockCodeService, mockAuthService, mockAuthRepo, mockTokenService, &mockCode, "new pass")
+
+	mockCodeService.On("StoreConsumed", mock.Anything, &mockCode).Return(nil)
+	mockCodeService.On("GetConsumed", mock.Anything, &mockCode).Return(mockCode.Value, mockCode.Identifier, time.Now(), nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "other pass", "encoded new pass").Return(false)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Nil(t, err)
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "other pass", "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockAuthRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestForgotPassResetUsedCodeRejectedWithoutGrace(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockSuccessfulForgotPassReset(mockCodeService, mockAuthService, mockAuthRepo, mockTokenService, &mockCode, "new pass")
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Nil(t, err)
+
+	for _, newPass := range []string{"new pass", "other pass"} {
+		result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, newPass, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	}
+
+	mockAuthRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockCodeService.AssertNotCalled(t, "GetConsumed", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetRetryWithinGrace(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	var mockCode domain.Code
+
+	mockNewPass := "new pass"
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+	mockCodeService.On("GetConsumed", mock.Anything, &mockCode).Return(mockCode.Value, mockCode.Identifier, time.Now().Add(-10*time.Second), nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(1, "uuid", mockCode.Identifier, "encoded new pass", domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockNewPass, "encoded new pass").Return(true)
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockCode.Identifier, Role: domain.RoleCustomer}, int64(43200)).Return("new token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60})
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, mockNewPass, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("new token"), result.Token)
+	mockAuthRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetTwoFactorPending(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockSuccessfulForgotPassReset(mockCodeService, mockAuthService, mockAuthRepo, mockTokenService, &mockCode, "new pass")
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(mockCode.Identifier, mockTwoFactorSecret, true, nil)
+	mockTwoFactorRepo.On("StoreChallenge", mock.Anything, mock.MatchedBy(func(c *domain.TwoFactorChallenge) bool {
+		return c.Login == mockCode.Identifier && c.Value != ""
+	})).Return(nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, mockTwoFactorRepo, acceptingAuthValidator(), domain.AuthConfig{TwoFactor: true})
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Token)
+	assert.NotEmpty(t, result.TwoFactorToken)
+	mockAuthRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockTwoFactorRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestForgotPassResetRetryTwoFactorPending(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value"}
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+	mockCodeService.On("GetConsumed", mock.Anything, &mockCode).Return(mockCode.Value, mockCode.Identifier, time.Now().Add(-10*time.Second), nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(1, "uuid", mockCode.Identifier, "encoded new pass", domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "new pass", "encoded new pass").Return(true)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, mockCode.Identifier).Return(mockCode.Identifier, mockTwoFactorSecret, true, nil)
+	mockTwoFactorRepo.On("StoreChallenge", mock.Anything, mock.Anything).Return(nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, mockCodeService, nil, mockAuthRepo, nil, nil, nil, nil, nil, mockTwoFactorRepo, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60, TwoFactor: true})
+
+	result, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Token)
+	assert.NotEmpty(t, result.TwoFactorToken)
+}
+
+func TestForgotPassResetRetryAfterGrace(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockCode domain.Code
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+	mockCodeService.On("GetConsumed", mock.Anything, &mockCode).Return(mockCode.Value, mockCode.Identifier, time.Now().Add(-5*time.Minute), nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassResetRetryGetConsumedError(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	var mockCode domain.Code
+
+	mockCode.Identifier = "identifier"
+	mockCode.Value = "Value"
+
+	mockCodeService.On("ValidateCode", mock.Anything, &mockCode).Return(false, nil)
+	mockCodeService.On("GetConsumed", mock.Anything, &mockCode).Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetRetryGraceSeconds: 60})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+}
+
+func TestForgotPassCodeUnsupportedChannel(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), "valid login", "pigeon")
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+}
+
+func TestForgotPassCodeEmailChannel(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockCodeService := new(mocks.MockCodeService)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockLogin := "valid login"
+
+	mockUserRepo.On("GetByEmail", mock.Anything, mockLogin).Return(1, "uuid", "user email", "user first name", "user last name", "user phone number", "user address city", "user address state", "user address neighborhood", "user address street", "user address number", "user address zipcode", nil)
+
+	mockCodeService.On("LastIssuedAt", mock.Anything, mockLogin).Return(time.Time{}, nil)
+	mockCodeService.On("GenerateNewCode", mock.Anything, mockLogin, domain.CodeChannelEmail, int8(6), true, false, 30*time.Minute).Return("generated code", mockLogin, domain.CodeChannelEmail, nil)
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "email"
+	messageConf.To = "user email"
+	messageConf.Subject = "Recuperação de senha"
+	messageConf.Message = "O código para recuperar sua senha é generated code"
+
+	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, mockMessageService, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ForgotPassCode(context.Background(), mockLogin, domain.CodeChannelEmail)
+
+	assert.Nil(t, err)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestForgotPassResetSingleChannelKeepsChannel(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value", Channel: domain.CodeChannelPhone}
+
+	mockCodeService.On("ValidateCode", mock.Anything, &domain.Code{Identifier: "identifier", Value: "Value", Channel: domain.CodeChannelPhone}).Return(false, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{ResetCodeSingleChannel: true})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+	mockCodeService.AssertExpectations(t)
+}
+
+func TestForgotPassResetWithoutSingleChannelIgnoresChannel(t *testing.T) {
+	mockCodeService := new(mocks.MockCodeService)
+
+	mockCode := domain.Code{Identifier: "identifier", Value: "Value", Channel: domain.CodeChannelPhone}
+
+	mockCodeService.On("ValidateCode", mock.Anything, &domain.Code{Identifier: "identifier", Value: "Value"}).Return(false, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, mockCodeService, nil, nil, nil, nil, nil, nil, nil, nil, acceptingAuthValidator(), domain.AuthConfig{})
+
+	_, err := authUseCase.ForgotPassReset(context.Background(), &mockCode, "new pass", "")
+
+	assert.Error(t, err)
+	mockCodeService.AssertExpectations(t)
+}
+
+func TestVerifyTokenExpired(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("expired token")).Return(nil, errors.New("token is expired"))
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.VerifyToken(context.Background(), domain.Token("expired token"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	mockRevokedTokenRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+}
+
+func TestVerifyTokenRevoked(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("revoked token")).Return("token id", "valid login", domain.RoleCustomer, "", time.Now().Add(time.Hour), nil)
+
+	mockRevokedTokenRepo.On("Exists", mock.Anything, "token id").Return(true, nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.VerifyToken(context.Background(), domain.Token("revoked token"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestVerifyTokenRevocationCheckError(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", time.Now().Add(time.Hour), nil)
+
+	mockRevokedTokenRepo.On("Exists", mock.Anything, "token id").Return(false, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.VerifyToken(context.Background(), domain.Token("valid token"))
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestVerifyTokenUnknownAudience(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "other-app", time.Now().Add(time.Hour), nil)
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, conf)
+
+	_, err := authUseCase.VerifyToken(context.Background(), domain.Token("valid token"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	mockRevokedTokenRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+}
+
+func TestVerifyTokenClientAudience(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "e-commerce-web", time.Now().Add(time.Hour), nil)
+
+	mockRevokedTokenRepo.On("Exists", mock.Anything, "token id").Return(false, nil)
+
+	conf := domain.AuthConfig{ClientAudiences: map[string]string{"web": "e-commerce-web"}}
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, conf)
+
+	info, err := authUseCase.VerifyToken(context.Background(), domain.Token("valid token"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "e-commerce-web", info.Audience)
+}
+
+func TestVerifyToken(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleAdmin, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Exists", mock.Anything, "token id").Return(false, nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	info, err := authUseCase.VerifyToken(context.Background(), domain.Token("valid token"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TokenInfo{ID: "token id", Info: "valid login", Role: domain.RoleAdmin, ExpiresAt: expiresAt}, info)
+}
+
+func TestLogoutGetInfoError(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("invalid token")).Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("invalid token"), "")
+
+	assert.Error(t, err)
+	mockRevokedTokenRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestLogoutTokenWithoutID(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("", "valid login", domain.RoleCustomer, "", time.Now(), nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), "")
+
+	assert.Error(t, err)
+	mockRevokedTokenRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestLogoutStoreRevokedTokenError(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Store", mock.Anything, &domain.RevokedToken{ID: "token id", ExpiresAt: expiresAt}).Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), "")
+
+	assert.Error(t, err)
+}
+
+func TestLogoutSuccess(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Store", mock.Anything, &domain.RevokedToken{ID: "token id", ExpiresAt: expiresAt}).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), "")
+
+	assert.Nil(t, err)
+	mockRevokedTokenRepo.AssertExpectations(t)
+}
+
+func TestLogoutDeletesRefreshToken(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Store", mock.Anything, &domain.RevokedToken{ID: "token id", ExpiresAt: expiresAt}).Return(nil)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "refresh token").Return("refresh token", "valid login", "", expiresAt, nil)
+	mockRefreshTokenRepo.On("DeleteByValue", mock.Anything, "refresh token").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), domain.Token("refresh token"))
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertNotCalled(t, "DeleteByLogin", mock.Anything, mock.Anything)
+}
+
+func TestLogoutKeepsRefreshTokenOfOtherLogin(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "refresh token").Return("refresh token", "other login", "", expiresAt, nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), domain.Token("refresh token"))
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertNotCalled(t, "DeleteByValue", mock.Anything, mock.Anything)
+}
+
+func TestLogoutWithoutRefreshTokenDeletesAllOfLogin(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockRevokedTokenRepo := new(mocks.MockRevokedTokenRepository)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("valid token")).Return("token id", "valid login", domain.RoleCustomer, "", expiresAt, nil)
+
+	mockRevokedTokenRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	mockRefreshTokenRepo.On("DeleteByLogin", mock.Anything, "valid login").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, mockRevokedTokenRepo, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	err := authUseCase.Logout(context.Background(), domain.Token("valid token"), "")
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestRefreshTokenGetByValueError(t *testing.T) {
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "refresh token").Return(nil, errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.RefreshToken(context.Background(), domain.Token("refresh token"))
+
+	assert.Error(t, err)
+}
+
+func TestRefreshTokenUnknown(t *testing.T) {
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "unknown refresh token").Return(nil, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{})
+
+	_, err := authUseCase.RefreshToken(context.Background(), domain.Token("unknown refresh token"))
+
+	assert.Error(t, err)
+	mockRefreshTokenRepo.AssertNotCalled(t, "DeleteByValue", mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenExpired(t *testing.T) {
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "refresh token").Return("refresh token", "valid login", "", time.Now().Add(-time.Minute), nil)
+	mockRefreshTokenRepo.On("DeleteByValue", mock.Anything, "refresh token").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	_, err := authUseCase.RefreshToken(context.Background(), domain.Token("refresh token"))
+
+	assert.Error(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	mockRefreshTokenRepo.On("GetByValue", mock.Anything, "refresh token").Return("refresh token", "valid login", "e-commerce-web", time.Now().Add(time.Hour), nil)
+	mockRefreshTokenRepo.On("DeleteByValue", mock.Anything, "refresh token").Return(nil)
+	mockRefreshTokenRepo.On("Store", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+		return rt.Value != "refresh token" && rt.Login == "valid login" && rt.Audience == "e-commerce-web"
+	})).Return(nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "uuid", "valid login", "hashed password", domain.RoleAdmin, false, nil)
+
+	tokenInfo := domain.TokenInfo{Info: "valid login", Role: domain.RoleAdmin, Audience: "e-commerce-web"}
+
+	mockTokenService.On("Sign", mock.Anything, tokenInfo, int64(15)).Return("new token", nil)
+
+	authUseCase := NewAuthUseCase(nil, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, nil, domain.AuthConfig{TokenTTLMinutes: 15, RefreshTokenTTLMinutes: 60})
+
+	result, err := authUseCase.RefreshToken(context.Background(), domain.Token("refresh token"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Token("new token"), result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.NotEqual(t, domain.Token("refresh token"), result.RefreshToken)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestVerifyEmailNotPending(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return(nil, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "123456")
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailWrongCode(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", "123456", time.Now().Add(time.Hour), 0, nil)
+	mockVerificationRepo.On("IncrementAttempts", mock.Anything, "valid login").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "654321")
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+	mockVerificationRepo.AssertExpectations(t)
+	mockVerificationRepo.AssertNotCalled(t, "DeleteByLogin", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailWrongCodeAttemptsExhausted(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", "123456", time.Now().Add(time.Hour), 4, nil)
+	mockVerificationRepo.On("DeleteByLogin", mock.Anything, "valid login").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "654321")
+
+	assert.ErrorIs(t, err, domain.ErrTooManyRequests)
+	mockAuthRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+	mockVerificationRepo.AssertExpectations(t)
+	mockVerificationRepo.AssertNotCalled(t, "IncrementAttempts", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailExpiredCode(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", "123456", time.Now().Add(-time.Minute), 0, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "123456")
+
+	assert.Error(t, err)
+	mockAuthRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailMarkVerifiedError(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", "123456", time.Now().Add(time.Hour), 0, nil)
+
+	mockAuthRepo.On("MarkVerified", mock.Anything, "valid login").Return(errors.New("error message"))
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "123456")
+
+	assert.Error(t, err)
+	mockVerificationRepo.AssertNotCalled(t, "DeleteByLogin", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmailSuccess(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockVerificationRepo := new(mocks.MockEmailVerificationRepository)
+
+	mockVerificationRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", "123456", time.Now().Add(time.Hour), 0, nil)
+	mockVerificationRepo.On("DeleteByLogin", mock.Anything, "valid login").Return(nil)
+
+	mockAuthRepo.On("MarkVerified", mock.Anything, "valid login").Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, mockVerificationRepo, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.VerifyEmail(context.Background(), "valid login", "123456")
+
+	assert.Nil(t, err)
+	mockAuthRepo.AssertExpectations(t)
+	mockVerificationRepo.AssertExpectations(t)
+}
+
+func TestChangePasswordWrongOldPassword(t *testing.T) {
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "valid uuid", "valid login", "hashed pass", domain.RoleCustomer, true, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "wrong pass", "hashed pass").Return(false)
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ChangePassword(context.Background(), "valid login", "wrong pass", "New pass1$")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	mockAuthRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePasswordUnknownLogin(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "unknown login").Return(nil, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ChangePassword(context.Background(), "unknown login", "old pass", "New pass1$")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+}
+
+func TestChangePasswordSamePassword(t *testing.T) {
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "valid uuid", "valid login", "hashed pass", domain.RoleCustomer, true, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "Old pass1$", "hashed pass").Return(true)
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, nil, domain.AuthConfig{})
+
+	err := authUseCase.ChangePassword(context.Background(), "valid login", "Old pass1$", "Old pass1$")
+
+	assert.ErrorIs(t, err, domain.ErrPasswordUnchanged)
+	mockAuthService.AssertNotCalled(t, "EncodePass", mock.Anything, mock.Anything)
+	mockAuthRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePasswordRejectedByPolicy(t *testing.T) {
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "valid uuid", "valid login", "hashed pass", domain.RoleCustomer, true, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "Old pass1$", "hashed pass").Return(true)
+
+	mockAuthValidator.On("ValidatePassword", mock.Anything, "weak").Return(false, "password need to have a symbol character")
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, mockAuthValidator, domain.AuthConfig{})
+
+	err := authUseCase.ChangePassword(context.Background(), "valid login", "Old pass1$", "weak")
+
+	assert.ErrorIs(t, err, domain.ErrWeakPassword)
+	assert.Contains(t, err.Error(), "password need to have a symbol character")
+	mockAuthService.AssertNotCalled(t, "EncodePass", mock.Anything, mock.Anything)
+	mockAuthRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePasswordSuccess(t *testing.T) {
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "valid uuid", "valid login", "hashed pass", domain.RoleCustomer, true, nil)
+	mockAuthRepo.On("UpdatePassword", mock.Anything, "valid login", "new hashed pass").Return(nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "Old pass1$", "hashed pass").Return(true)
+	mockAuthService.On("EncodePass", mock.Anything, "New pass1$").Return("new hashed pass", nil)
+
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockAuthValidator.On("ValidatePassword", mock.Anything, "New pass1$").Return(true, "")
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, nil, mockAuthValidator, domain.AuthConfig{})
+
+	err := authUseCase.ChangePassword(context.Background(), "valid login", "Old pass1$", "New pass1$")
+
+	assert.NoError(t, err)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestChangePasswordPurgesRefreshTokens(t *testing.T) {
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthValidator := new(mocks.MockAuthValidator)
+	mockRefreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "valid uuid", "valid login", "hashed pass", domain.RoleCustomer, true, nil)
+	mockAuthRepo.On("UpdatePassword", mock.Anything, "valid login", "new hashed pass").Return(nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, "Old pass1$", "hashed pass").Return(true)
+	mockAuthService.On("EncodePass", mock.Anything, "New pass1$").Return("new hashed pass", nil)
+
+	mockAuthValidator.On("ValidatePassword", mock.Anything, "New pass1$").Return(true, "")
+
+	mockRefreshTokenRepo.On("DeleteByLogin", mock.Anything, "valid login").Return(nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, nil, nil, nil, mockAuthRepo, nil, nil, nil, mockRefreshTokenRepo, nil, nil, mockAuthValidator, domain.AuthConfig{RefreshTokenTTLMinutes: 60})
+
+	err := authUseCase.ChangePassword(context.Background(), "valid login", "Old pass1$", "New pass1$")
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+const mockTwoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTotpCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+
+	assert.Equal(t, "287082", totpCode(secret, time.Unix(59, 0)))
+	assert.Equal(t, "081804", totpCode(secret, time.Unix(1111111109, 0)))
+}
+
+func TestEnableTwoFactorNotAvailable(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{})
+
+	_, _, err := authUseCase.EnableTwoFactor(context.Background(), "valid login")
+
+	assert.Error(t, err)
+	mockTwoFactorRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestEnableTwoFactorAlreadyEnabled(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", mockTwoFactorSecret, true, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true})
+
+	_, _, err := authUseCase.EnableTwoFactor(context.Background(), "valid login")
+
+	assert.Error(t, err)
+	mockTwoFactorRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return(nil, nil)
+	mockTwoFactorRepo.On("Store", mock.Anything, mock.MatchedBy(func(tf *domain.TwoFactor) bool {
+		return tf.Login == "valid login" && len(tf.Secret) == 32 && !tf.Enabled
+	})).Return(nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true, TwoFactorIssuer: "Shop"})
+
+	secret, otpauthURL, err := authUseCase.EnableTwoFactor(context.Background(), "valid login")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth://totp/Shop:valid%20login?secret="+secret+"&issuer=Shop", otpauthURL)
+	mockTwoFactorRepo.AssertExpectations(t)
+}
+
+func TestVerifyTwoFactorInvalidCode(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", mockTwoFactorSecret, false, nil)
+
+	uc := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	secret, _ := totpEncoding.DecodeString(mockTwoFactorSecret)
+
+	err := uc.VerifyTwoFactor(context.Background(), "valid login", totpCode(secret, now.Add(-2*time.Minute)))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactor)
+	mockTwoFactorRepo.AssertNotCalled(t, "Enable", mock.Anything, mock.Anything)
+}
+
+func TestVerifyTwoFactorConfirmsSecret(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", mockTwoFactorSecret, false, nil)
+	mockTwoFactorRepo.On("Enable", mock.Anything, "valid login").Return(nil)
+
+	uc := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	secret, _ := totpEncoding.DecodeString(mockTwoFactorSecret)
+
+	err := uc.VerifyTwoFactor(context.Background(), "valid login", totpCode(secret, now.Add(-30*time.Second)))
+
+	assert.NoError(t, err)
+	mockTwoFactorRepo.AssertExpectations(t)
+}
+
+func TestLoginTwoFactorPending(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "hash", domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "hash").Return(false)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(mockAuth.Login, mockTwoFactorSecret, true, nil)
+	mockTwoFactorRepo.On("StoreChallenge", mock.Anything, mock.MatchedBy(func(c *domain.TwoFactorChallenge) bool {
+		return c.Login == mockAuth.Login && c.Value != "" && c.ExpiresAt.Equal(now.Add(5*time.Minute))
+	})).Return(nil)
+
+	uc := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	result, err := uc.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Token)
+	assert.NotEmpty(t, result.TwoFactorToken)
+	mockTwoFactorRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactorNotConfirmed(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockAuthService := new(mocks.MockAuthService)
+	mockTokenService := new(mocks.MockTokenService)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockAuth := domain.Auth{Login: "valid login", Password: "valid password"}
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(1, "uuid", mockAuth.Login, "hash", domain.RoleCustomer, false, nil)
+
+	mockAuthService.On("PassIsEqualHashedPass", mock.Anything, mockAuth.Password, "hash").Return(true)
+	mockAuthService.On("NeedsRehash", mock.Anything, "hash").Return(false)
+
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, mockAuth.Login).Return(mockAuth.Login, mockTwoFactorSecret, false, nil)
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: mockAuth.Login, Role: domain.RoleCustomer}, int64(43200)).Return("valid token", nil)
+
+	authUseCase := NewAuthUseCase(mockAuthService, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true})
+
+	result, err := authUseCase.Login(context.Background(), &mockAuth, domain.LoginInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	assert.Empty(t, result.TwoFactorToken)
+	mockTwoFactorRepo.AssertNotCalled(t, "StoreChallenge", mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactorUnknownToken(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	mockTwoFactorRepo.On("GetChallengeByValue", mock.Anything, "pending token").Return(nil, nil)
+
+	authUseCase := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true})
+
+	_, err := authUseCase.LoginTwoFactor(context.Background(), domain.Token("pending token"), "123456")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestLoginTwoFactorExpiredToken(t *testing.T) {
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockTwoFactorRepo.On("GetChallengeByValue", mock.Anything, "pending token").Return("pending token", "valid login", "", now.Add(-time.Second), nil)
+	mockTwoFactorRepo.On("DeleteChallengeByValue", mock.Anything, "pending token").Return(nil)
+
+	uc := NewAuthUseCase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	_, err := uc.LoginTwoFactor(context.Background(), domain.Token("pending token"), "123456")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	mockTwoFactorRepo.AssertNotCalled(t, "GetByLogin", mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactorInvalidCode(t *testing.T) {
+	mockTokenService := new(mocks.MockTokenService)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockTwoFactorRepo.On("GetChallengeByValue", mock.Anything, "pending token").Return("pending token", "valid login", "", now.Add(time.Minute), nil)
+	mockTwoFactorRepo.On("DeleteChallengeByValue", mock.Anything, "pending token").Return(nil)
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", mockTwoFactorSecret, true, nil)
+
+	uc := NewAuthUseCase(nil, mockTokenService, nil, nil, nil, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	_, err := uc.LoginTwoFactor(context.Background(), domain.Token("pending token"), "not a code")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactor)
+	mockTwoFactorRepo.AssertCalled(t, "DeleteChallengeByValue", mock.Anything, "pending token")
+	mockTokenService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactorCompletesLogin(t *testing.T) {
+	mockAuthRepo := new(mocks.MockAuthRepository)
+	mockTokenService := new(mocks.MockTokenService)
+	mockTwoFactorRepo := new(mocks.MockTwoFactorRepository)
+
+	now := time.Unix(1650000000, 0)
+
+	mockTwoFactorRepo.On("GetChallengeByValue", mock.Anything, "pending token").Return("pending token", "valid login", "mobile", now.Add(time.Minute), nil)
+	mockTwoFactorRepo.On("DeleteChallengeByValue", mock.Anything, "pending token").Return(nil)
+	mockTwoFactorRepo.On("GetByLogin", mock.Anything, "valid login").Return("valid login", mockTwoFactorSecret, true, nil)
+
+	mockAuthRepo.On("GetByLogin", mock.Anything, "valid login").Return(1, "uuid", "valid login", "hash", domain.RoleAdmin, true, nil)
+
+	mockTokenService.On("Sign", mock.Anything, domain.TokenInfo{Info: "valid login", Role: domain.RoleAdmin, Audience: "mobile", Verified: true}, int64(43200)).Return("valid token", nil)
+
+	uc := NewAuthUseCase(nil, mockTokenService, nil, nil, mockAuthRepo, nil, nil, nil, nil, nil, mockTwoFactorRepo, nil, domain.AuthConfig{TwoFactor: true}).(*authUseCase)
+	uc.now = func() time.Time { return now }
+
+	secret, _ := totpEncoding.DecodeString(mockTwoFactorSecret)
+
+	result, err := uc.LoginTwoFactor(context.Background(), domain.Token("pending token"), totpCode(secret, now))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Token("valid token"), result.Token)
+	mockTwoFactorRepo.AssertNotCalled(t, "Enable", mock.Anything, mock.Anything)
 }
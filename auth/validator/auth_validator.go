@@ -2,16 +2,31 @@ package validator
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"net/mail"
 	"unicode"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
 
-type authValidator struct{}
+// basePasswordPolicy is used when no rule is configured, it keeps the rules
+// passwords always had: 3 characters with an uppercase, a number and a symbol.
+var basePasswordPolicy = domain.PasswordPolicy{MinLength: 3, RequireUpper: true, RequireDigit: true, RequireSymbol: true}
 
-func NewAuthValidator() *authValidator {
-	return &authValidator{}
+type authValidator struct {
+	policy        domain.PasswordPolicy
+	breachChecker domain.BreachChecker
+}
+
+// NewAuthValidator builds the validator, passing a nil breach checker disables
+// the known breached passwords check. The zero policy keeps basePasswordPolicy.
+func NewAuthValidator(policy domain.PasswordPolicy, bc domain.BreachChecker) *authValidator {
+	if policy == (domain.PasswordPolicy{}) {
+		policy = basePasswordPolicy
+	}
+
+	return &authValidator{policy: policy, breachChecker: bc}
 }
 
 func (av *authValidator) Validate(ctx context.Context, a *domain.Auth) (domain.IsValid, domain.Message) {
@@ -23,49 +38,71 @@ func (av *authValidator) Validate(ctx context.Context, a *domain.Auth) (domain.I
 		return false, "login is not a valid email"
 	}
 
-	if len(a.Password) < 3 {
-		return false, "password need to have at least 3 characters"
-	}
-
-	hasUpper := false
+	return true, ""
+}
 
-	for _, ch := range a.Password {
-		if unicode.IsUpper(ch) {
-			hasUpper = true
-		}
+// ValidatePassword checks a new password against the policy and the breach
+// checker, it is kept out of Validate so logins with older passwords still work.
+func (av *authValidator) ValidatePassword(ctx context.Context, password string) (domain.IsValid, domain.Message) {
+	if len(password) < av.minLength() {
+		return false, domain.Message(fmt.Sprintf("password need to have at least %d characters", av.minLength()))
 	}
 
-	if !hasUpper {
+	if av.policy.RequireUpper && !containsRune(password, unicode.IsUpper) {
 		return false, "password need to have a uppercase character"
 	}
 
-	hasNumber := false
-
-	for _, ch := range a.Password {
-		if unicode.IsNumber(ch) {
-			hasNumber = true
-		}
+	if av.policy.RequireLower && !containsRune(password, unicode.IsLower) {
+		return false, "password need to have a lowercase character"
 	}
 
-	if !hasNumber {
+	if av.policy.RequireDigit && !containsRune(password, unicode.IsNumber) {
 		return false, "password need to have a number"
 	}
 
-	hasSymbol := false
+	if av.policy.RequireSymbol && !containsRune(password, unicode.IsSymbol) {
+		return false, "password need to have a symbol character"
+	}
 
-	for _, ch := range a.Password {
-		if unicode.IsSymbol(ch) {
-			hasSymbol = true
-		}
+	if av.policy.MinEntropyScore > 0 && passwordEntropyScore(password) < av.policy.MinEntropyScore {
+		return false, "password is too easy to guess, try a longer passphrase"
 	}
 
-	if !hasSymbol {
-		return false, "password need to have a symbol character"
+	if av.breachChecker != nil {
+		isBreached, err := av.breachChecker.IsBreached(ctx, password)
+
+		// an unreachable breach database must not block sign ups, so it fails open
+		if err != nil {
+			log.Printf("Error trying to check if password is breached: %s", err.Error())
+		}
+
+		if isBreached {
+			return false, "password appeared in a data breach, choose another one"
+		}
 	}
 
 	return true, ""
 }
 
+// minLength keeps the historical minimum of 3 characters when the policy doesn't set one.
+func (av *authValidator) minLength() int {
+	if av.policy.MinLength > 0 {
+		return av.policy.MinLength
+	}
+
+	return 3
+}
+
+func containsRune(s string, f func(rune) bool) bool {
+	for _, ch := range s {
+		if f(ch) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (av *authValidator) ValidateLogin(ctx context.Context, login string) (domain.IsValid, domain.Message) {
 	if login == "" {
 		return false, "login or password can not be empty"
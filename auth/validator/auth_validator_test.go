@@ -2,74 +2,166 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestValidateEmptyLoginOrPassword(t *testing.T) {
-	isLoginValid, isLoginValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "", Password: "valid pass"})
+	isLoginValid, isLoginValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).Validate(context.Background(), &domain.Auth{Login: "", Password: "valid pass"})
 
 	assert.False(t, bool(isLoginValid))
 	assert.NotEmpty(t, isLoginValidMessage)
 
-	isPassValid, isPassValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "valid login", Password: ""})
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).Validate(context.Background(), &domain.Auth{Login: "valid login", Password: ""})
 
 	assert.False(t, bool(isPassValid))
 	assert.NotEmpty(t, isPassValidMessage)
 }
 
 func TestValidateEmailInvalid(t *testing.T) {
-	isLoginValid, isLoginValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "invalid login", Password: "valid pass"})
+	isLoginValid, isLoginValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).Validate(context.Background(), &domain.Auth{Login: "invalid login", Password: "valid pass"})
 
 	assert.False(t, bool(isLoginValid))
 	assert.NotEmpty(t, isLoginValidMessage)
 }
 
 func TestValidatePasswordWith2Char(t *testing.T) {
-	isPassValid, isPassValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pa"})
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidatePassword(context.Background(), "pa")
 
 	assert.False(t, bool(isPassValid))
 	assert.NotEmpty(t, isPassValidMessage)
 }
 
 func TestValidatePasswordWithNoUpper(t *testing.T) {
-	isPassValid, isPassValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pass"})
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidatePassword(context.Background(), "pass")
 
 	assert.False(t, bool(isPassValid))
 	assert.NotEmpty(t, isPassValidMessage)
 }
 
 func TestValidatePasswordWithNoNumber(t *testing.T) {
-	isPassValid, isPassValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pasS"})
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidatePassword(context.Background(), "pasS")
 
 	assert.False(t, bool(isPassValid))
 	assert.NotEmpty(t, isPassValidMessage)
 }
 
 func TestValidatePasswordWithNoSymbol(t *testing.T) {
-	isPassValid, isPassValidMessage := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pasS1"})
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidatePassword(context.Background(), "pasS1")
 
 	assert.False(t, bool(isPassValid))
 	assert.NotEmpty(t, isPassValidMessage)
 }
 
 func TestValidateAuthValid(t *testing.T) {
-	isAuthValid, _ := NewAuthValidator().Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pasS1$"})
+	isAuthValid, _ := NewAuthValidator(domain.PasswordPolicy{}, nil).Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "pasS1$"})
 
 	assert.True(t, bool(isAuthValid))
 }
 
+func TestValidateSkipsPasswordPolicy(t *testing.T) {
+	mockBreachChecker := new(mocks.MockBreachChecker)
+
+	isAuthValid, _ := NewAuthValidator(domain.PasswordPolicy{MinLength: 8, RequireSymbol: true, MinEntropyScore: 3}, mockBreachChecker).Validate(context.Background(), &domain.Auth{Login: "login@email.com", Password: "old"})
+
+	assert.True(t, bool(isAuthValid))
+	mockBreachChecker.AssertNotCalled(t, "IsBreached", mock.Anything, mock.Anything)
+}
+
+func TestValidatePasswordWithNoLower(t *testing.T) {
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{RequireLower: true}, nil).ValidatePassword(context.Background(), "PASS1$")
+
+	assert.False(t, bool(isPassValid))
+	assert.Equal(t, domain.Message("password need to have a lowercase character"), isPassValidMessage)
+}
+
+func TestValidatePasswordBelowMinLength(t *testing.T) {
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{MinLength: 8}, nil).ValidatePassword(context.Background(), "pasS1$x")
+
+	assert.False(t, bool(isPassValid))
+	assert.Equal(t, domain.Message("password need to have at least 8 characters"), isPassValidMessage)
+}
+
+func TestValidatePasswordWithoutRequiredClasses(t *testing.T) {
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{MinLength: 8}, nil).ValidatePassword(context.Background(), "longpassphrase")
+
+	assert.True(t, bool(isPassValid))
+}
+
+func TestValidatePasswordWithAllRules(t *testing.T) {
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}, nil).ValidatePassword(context.Background(), "pasS1$word")
+
+	assert.True(t, bool(isPassValid))
+}
+
+func TestValidatePasswordBelowMinEntropyScore(t *testing.T) {
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{MinEntropyScore: 3}, nil).ValidatePassword(context.Background(), "Password1$")
+
+	assert.False(t, bool(isPassValid))
+	assert.NotEmpty(t, isPassValidMessage)
+}
+
+func TestValidatePasswordAboveMinEntropyScore(t *testing.T) {
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{MinEntropyScore: 3}, nil).ValidatePassword(context.Background(), "Tulip$Orbit7Canyon$Frost")
+
+	assert.True(t, bool(isPassValid))
+}
+
+func TestValidatePasswordBreached(t *testing.T) {
+	mockBreachChecker := new(mocks.MockBreachChecker)
+
+	mockBreachChecker.On("IsBreached", mock.Anything, "pasS1$").Return(true, nil)
+
+	isPassValid, isPassValidMessage := NewAuthValidator(domain.PasswordPolicy{}, mockBreachChecker).ValidatePassword(context.Background(), "pasS1$")
+
+	assert.False(t, bool(isPassValid))
+	assert.NotEmpty(t, isPassValidMessage)
+}
+
+func TestValidatePasswordNotBreached(t *testing.T) {
+	mockBreachChecker := new(mocks.MockBreachChecker)
+
+	mockBreachChecker.On("IsBreached", mock.Anything, "pasS1$").Return(false, nil)
+
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{}, mockBreachChecker).ValidatePassword(context.Background(), "pasS1$")
+
+	assert.True(t, bool(isPassValid))
+	mockBreachChecker.AssertExpectations(t)
+}
+
+func TestValidatePasswordBreachCheckError(t *testing.T) {
+	mockBreachChecker := new(mocks.MockBreachChecker)
+
+	mockBreachChecker.On("IsBreached", mock.Anything, "pasS1$").Return(false, errors.New("error message"))
+
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{}, mockBreachChecker).ValidatePassword(context.Background(), "pasS1$")
+
+	assert.True(t, bool(isPassValid))
+}
+
+func TestValidatePasswordBreachNotCheckedWhenWeak(t *testing.T) {
+	mockBreachChecker := new(mocks.MockBreachChecker)
+
+	isPassValid, _ := NewAuthValidator(domain.PasswordPolicy{}, mockBreachChecker).ValidatePassword(context.Background(), "pass")
+
+	assert.False(t, bool(isPassValid))
+	mockBreachChecker.AssertNotCalled(t, "IsBreached", mock.Anything, mock.Anything)
+}
+
 func TestValidateLoginEmptyLogin(t *testing.T) {
-	isLoginValid, isLoginValidMessage := NewAuthValidator().ValidateLogin(context.Background(), "")
+	isLoginValid, isLoginValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidateLogin(context.Background(), "")
 
 	assert.False(t, bool(isLoginValid))
 	assert.NotEmpty(t, isLoginValidMessage)
 }
 
 func TestValidateLoginEmailInvalid(t *testing.T) {
-	isLoginValid, isLoginValidMessage := NewAuthValidator().ValidateLogin(context.Background(), "invalid login")
+	isLoginValid, isLoginValidMessage := NewAuthValidator(domain.PasswordPolicy{}, nil).ValidateLogin(context.Background(), "invalid login")
 
 	assert.False(t, bool(isLoginValid))
 	assert.NotEmpty(t, isLoginValidMessage)
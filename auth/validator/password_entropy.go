@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords is ordered by popularity, the index is used as the guess rank.
+var commonPasswords = []string{
+	"password", "123456", "qwerty", "letmein", "welcome", "admin", "monkey",
+	"dragon", "iloveyou", "football", "baseball", "master", "sunshine",
+	"princess", "shadow", "superman", "trustno", "whatever", "starwars",
+	"passw0rd", "login", "abc123", "hello", "freedom", "secret", "summer",
+	"winter", "spring", "autumn", "michael", "charlie", "jordan", "pokemon",
+}
+
+var leetReplacer = strings.NewReplacer("@", "a", "4", "a", "0", "o", "1", "i", "!", "i", "3", "e", "$", "s", "5", "s", "7", "t")
+
+// passwordEntropyScore estimates how hard a password is to guess, in the spirit
+// of zxcvbn: dictionary words, repeats and sequences are cheap to guess while
+// the remaining characters are priced by their character pool. The result is
+// a score from 0 (trivial) to 4 (very strong).
+func passwordEntropyScore(pass string) int {
+	bits := passwordEntropyBits(pass)
+
+	switch {
+	case bits < 10:
+		return 0
+	case bits < 20:
+		return 1
+	case bits < 27:
+		return 2
+	case bits < 33:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func passwordEntropyBits(pass string) float64 {
+	runes := []rune(pass)
+	lower := []rune(strings.ToLower(pass))
+	unleet := []rune(leetReplacer.Replace(string(lower)))
+
+	matched := make([]bool, len(runes))
+	bits := 0.0
+
+	for rank, word := range commonPasswords {
+		w := []rune(word)
+		for i := 0; i+len(w) <= len(runes); i++ {
+			if anyMatched(matched[i : i+len(w)]) {
+				continue
+			}
+
+			if string(lower[i:i+len(w)]) != word && (len(unleet) != len(runes) || string(unleet[i:i+len(w)]) != word) {
+				continue
+			}
+
+			bits += math.Log2(float64(rank + 2))
+
+			for j := i; j < i+len(w); j++ {
+				matched[j] = true
+				if unicode.IsUpper(runes[j]) || lower[j] != w[j-i] {
+					bits++
+				}
+			}
+		}
+	}
+
+	var remaining []rune
+
+	for i, r := range runes {
+		if matched[i] {
+			bits += segmentEntropyBits(remaining)
+			remaining = nil
+			continue
+		}
+		remaining = append(remaining, r)
+	}
+
+	return bits + segmentEntropyBits(remaining)
+}
+
+// segmentEntropyBits prices a run of unmatched characters, collapsing repeated
+// characters and ascending or descending sequences like "aaa" or "123".
+func segmentEntropyBits(segment []rune) float64 {
+	if len(segment) == 0 {
+		return 0
+	}
+
+	pool := math.Log2(float64(charPoolSize(segment)))
+	bits := 0.0
+
+	for i := 0; i < len(segment); {
+		j := i + 1
+
+		if j < len(segment) {
+			step := segment[j] - segment[i]
+			if step >= -1 && step <= 1 {
+				for j < len(segment) && segment[j]-segment[j-1] == step {
+					j++
+				}
+			}
+		}
+
+		if j-i < 3 {
+			j = i + 1
+			bits += pool
+		} else {
+			bits += pool + math.Log2(float64(j-i))
+		}
+
+		i = j
+	}
+
+	return bits
+}
+
+func charPoolSize(segment []rune) int {
+	hasLower, hasUpper, hasNumber, hasOther := false, false, false, false
+
+	for _, ch := range segment {
+		switch {
+		case unicode.IsLower(ch):
+			hasLower = true
+		case unicode.IsUpper(ch):
+			hasUpper = true
+		case unicode.IsNumber(ch):
+			hasNumber = true
+		default:
+			hasOther = true
+		}
+	}
+
+	size := 0
+
+	if hasLower {
+		size += 26
+	}
+
+	if hasUpper {
+		size += 26
+	}
+
+	if hasNumber {
+		size += 10
+	}
+
+	if hasOther {
+		size += 33
+	}
+
+	return size
+}
+
+func anyMatched(matched []bool) bool {
+	for _, m := range matched {
+		if m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type cartMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCartMysqlRepository(conn *sql.DB) domain.CartRepository {
+	return &cartMysqlRepository{Conn: conn}
+}
+
+func (r *cartMysqlRepository) List(ctx context.Context, login string) ([]domain.CartItem, error) {
+	query := `SELECT product_uuid, quantity, unit_price FROM cart_item WHERE login = ? ORDER BY created_at;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var res []domain.CartItem
+
+	for rows.Next() {
+		var item domain.CartItem
+
+		if err := rows.Scan(&item.ProductUUID, &item.Quantity, &item.UnitPrice); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+// SetItem inserts the item or overwrites the quantity and price of the one already in the cart.
+func (r *cartMysqlRepository) SetItem(ctx context.Context, login string, item *domain.CartItem) error {
+	query := `INSERT INTO cart_item (login, product_uuid, quantity, unit_price, created_at) VALUES (?, ?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE quantity = VALUES(quantity), unit_price = VALUES(unit_price);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, login, item.ProductUUID, item.Quantity, item.UnitPrice); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *cartMysqlRepository) RemoveItem(ctx context.Context, login string, productUUID string) error {
+	query := `DELETE FROM cart_item WHERE login = ? AND product_uuid = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, login, productUUID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *cartMysqlRepository) Clear(ctx context.Context, login string) error {
+	query := `DELETE FROM cart_item WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, login); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT product_uuid, quantity, unit_price FROM cart_item WHERE login = ? ORDER BY created_at;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnError(errors.New("error message"))
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	_, err = cartMysqlRepository.List(context.Background(), "login")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_uuid", "quantity", "unit_price"}).
+		AddRow("product 1", 2, 1990).
+		AddRow("product 2", 1, 500)
+
+	query := regexp.QuoteMeta("SELECT product_uuid, quantity, unit_price FROM cart_item WHERE login = ? ORDER BY created_at;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	items, err := cartMysqlRepository.List(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CartItem{{ProductUUID: "product 1", Quantity: 2, UnitPrice: 1990}, {ProductUUID: "product 2", Quantity: 1, UnitPrice: 500}}, items)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetItemError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO cart_item (login, product_uuid, quantity, unit_price, created_at) VALUES (?, ?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE quantity = VALUES(quantity), unit_price = VALUES(unit_price);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "product", int64(2), int64(1990)).WillReturnError(errors.New("error message"))
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	err = cartMysqlRepository.SetItem(context.Background(), "login", &domain.CartItem{ProductUUID: "product", Quantity: 2, UnitPrice: 1990})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO cart_item (login, product_uuid, quantity, unit_price, created_at) VALUES (?, ?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE quantity = VALUES(quantity), unit_price = VALUES(unit_price);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "product", int64(2), int64(1990)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	err = cartMysqlRepository.SetItem(context.Background(), "login", &domain.CartItem{ProductUUID: "product", Quantity: 2, UnitPrice: 1990})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM cart_item WHERE login = ? AND product_uuid = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "product").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	err = cartMysqlRepository.RemoveItem(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClear(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM cart_item WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 3))
+
+	cartMysqlRepository := NewCartMysqlRepository(db)
+
+	err = cartMysqlRepository.Clear(context.Background(), "login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type cartUseCase struct {
+	cartRepo    domain.CartRepository
+	productRepo domain.ProductRepository
+}
+
+func NewCartUseCase(cr domain.CartRepository, pr domain.ProductRepository) domain.CartUseCase {
+	return &cartUseCase{
+		cartRepo:    cr,
+		productRepo: pr,
+	}
+}
+
+func findItem(items []domain.CartItem, productUUID string) *domain.CartItem {
+	for i := range items {
+		if items[i].ProductUUID == productUUID {
+			return &items[i]
+		}
+	}
+
+	return nil
+}
+
+// setItem stores the item with the given quantity as long as the product has
+// enough stock, the unit price is snapshotted from the current product price.
+func (cu *cartUseCase) setItem(ctx context.Context, login string, productUUID string, quantity int64) error {
+	product, err := cu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil || !product.Active {
+		return fmt.Errorf("product %s not found", productUUID)
+	}
+
+	if quantity > product.StockQuantity {
+		return fmt.Errorf("%w: product %s has %d in stock, asked for %d", domain.ErrInsufficientStock, productUUID, product.StockQuantity, quantity)
+	}
+
+	return cu.cartRepo.SetItem(ctx, login, &domain.CartItem{ProductUUID: productUUID, Quantity: quantity, UnitPrice: product.Price})
+}
+
+// AddItem increments the quantity when the product is already in the cart.
+func (cu *cartUseCase) AddItem(ctx context.Context, login string, productUUID string, quantity int64) error {
+	if quantity < 1 {
+		return fmt.Errorf("quantity must be at least 1, got %d", quantity)
+	}
+
+	items, err := cu.cartRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if item := findItem(items, productUUID); item != nil {
+		quantity += item.Quantity
+	}
+
+	return cu.setItem(ctx, login, productUUID, quantity)
+}
+
+func (cu *cartUseCase) RemoveItem(ctx context.Context, login string, productUUID string) error {
+	return cu.cartRepo.RemoveItem(ctx, login, productUUID)
+}
+
+func (cu *cartUseCase) UpdateQuantity(ctx context.Context, login string, productUUID string, quantity int64) error {
+	if quantity < 1 {
+		return fmt.Errorf("quantity must be at least 1, got %d", quantity)
+	}
+
+	items, err := cu.cartRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if findItem(items, productUUID) == nil {
+		return fmt.Errorf("product %s is not in the cart of login %s", productUUID, login)
+	}
+
+	return cu.setItem(ctx, login, productUUID, quantity)
+}
+
+func (cu *cartUseCase) GetCart(ctx context.Context, login string) (*domain.Cart, error) {
+	items, err := cu.cartRepo.List(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cart := &domain.Cart{Login: login, Items: items}
+
+	for _, item := range items {
+		cart.Total += item.UnitPrice * item.Quantity
+	}
+
+	return cart, nil
+}
+
+func (cu *cartUseCase) Clear(ctx context.Context, login string) error {
+	return cu.cartRepo.Clear(ctx, login)
+}
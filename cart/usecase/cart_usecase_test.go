@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockProductInStock(pr *mocks.MockProductRepository, stock int) {
+	pr.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", stock, true, nil)
+}
+
+func TestAddItemInvalidQuantity(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.AddItem(context.Background(), "login", "product", 0)
+
+	assert.Error(t, err)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddItemProductNotFound(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(nil, nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.AddItem(context.Background(), "login", "product", 1)
+
+	assert.Error(t, err)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddItem(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{}, nil)
+	mockProductInStock(mockProductRepo, 10)
+	mockCartRepo.On("SetItem", mock.Anything, "login", &domain.CartItem{ProductUUID: "product", Quantity: 2, UnitPrice: 1990}).Return(nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.AddItem(context.Background(), "login", "product", 2)
+
+	assert.NoError(t, err)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestAddItemMergesDuplicate(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 3, UnitPrice: 1500}}, nil)
+	mockProductInStock(mockProductRepo, 10)
+	mockCartRepo.On("SetItem", mock.Anything, "login", &domain.CartItem{ProductUUID: "product", Quantity: 5, UnitPrice: 1990}).Return(nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.AddItem(context.Background(), "login", "product", 2)
+
+	assert.NoError(t, err)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestAddItemExceedsStock(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 4, UnitPrice: 1990}}, nil)
+	mockProductInStock(mockProductRepo, 5)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.AddItem(context.Background(), "login", "product", 2)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateQuantityNotInCart(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{}, nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.UpdateQuantity(context.Background(), "login", "product", 2)
+
+	assert.Error(t, err)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateQuantityExceedsStock(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 1, UnitPrice: 1990}}, nil)
+	mockProductInStock(mockProductRepo, 5)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.UpdateQuantity(context.Background(), "login", "product", 6)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateQuantity(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 4, UnitPrice: 1990}}, nil)
+	mockProductInStock(mockProductRepo, 5)
+	mockCartRepo.On("SetItem", mock.Anything, "login", &domain.CartItem{ProductUUID: "product", Quantity: 1, UnitPrice: 1990}).Return(nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	err := cartUseCase.UpdateQuantity(context.Background(), "login", "product", 1)
+
+	assert.NoError(t, err)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestGetCartError(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return(nil, errors.New("error message"))
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	_, err := cartUseCase.GetCart(context.Background(), "login")
+
+	assert.Error(t, err)
+}
+
+func TestGetCart(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product 1", Quantity: 2, UnitPrice: 1990}, {ProductUUID: "product 2", Quantity: 1, UnitPrice: 500}}, nil)
+
+	cartUseCase := NewCartUseCase(mockCartRepo, mockProductRepo)
+
+	cart, err := cartUseCase.GetCart(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, int64(4480), cart.Total)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
@@ -17,7 +18,7 @@ func NewCodeMysqlRepository(conn *sql.DB) domain.CodeRepository {
 }
 
 func (r *codeMysqlRepository) Store(ctx context.Context, c *domain.Code) error {
-	query := `INSERT INTO code (value, identifier) VALUES (?, ?);`
+	query := `INSERT INTO code (value, identifier, channel, expires_at, created_at) VALUES (?, ?, ?, ?, ?);`
 
 	stmt, err := r.Conn.PrepareContext(ctx, query)
 
@@ -25,7 +26,9 @@ func (r *codeMysqlRepository) Store(ctx context.Context, c *domain.Code) error {
 		return err
 	}
 
-	exec, err := stmt.ExecContext(ctx, c.Value, c.Identifier)
+	expiresAt := sql.NullTime{Time: c.ExpiresAt, Valid: !c.ExpiresAt.IsZero()}
+
+	exec, err := stmt.ExecContext(ctx, c.Value, c.Identifier, c.Channel, expiresAt, c.CreatedAt)
 
 	if err != nil {
 		return err
@@ -45,13 +48,14 @@ func (r *codeMysqlRepository) Store(ctx context.Context, c *domain.Code) error {
 }
 
 func (r *codeMysqlRepository) GetByValue(ctx context.Context, value string) (*domain.Code, error) {
-	query := `SELECT value, identifier FROM code WHERE value = ?;`
+	query := `SELECT value, identifier, channel, expires_at FROM code WHERE value = ?;`
 
 	row := r.Conn.QueryRowContext(ctx, query, value)
 
 	var res domain.Code
+	var expiresAt sql.NullTime
 
-	if err := row.Scan(&res.Value, &res.Identifier); err != nil {
+	if err := row.Scan(&res.Value, &res.Identifier, &res.Channel, &expiresAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -59,6 +63,8 @@ func (r *codeMysqlRepository) GetByValue(ctx context.Context, value string) (*do
 		return nil, err
 	}
 
+	res.ExpiresAt = expiresAt.Time
+
 	return &res, nil
 }
 
@@ -89,3 +95,63 @@ func (r *codeMysqlRepository) DeleteByValue(ctx context.Context, value string) e
 
 	return nil
 }
+
+func (r *codeMysqlRepository) StoreConsumed(ctx context.Context, cc *domain.ConsumedCode) error {
+	query := `INSERT INTO code_consumed (value, identifier, consumed_at) VALUES (?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, cc.Value, cc.Identifier, cc.ConsumedAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store consumed code with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *codeMysqlRepository) GetConsumedByValue(ctx context.Context, value string) (*domain.ConsumedCode, error) {
+	query := `SELECT value, identifier, consumed_at FROM code_consumed WHERE value = ? ORDER BY consumed_at DESC LIMIT 1;`
+
+	row := r.Conn.QueryRowContext(ctx, query, value)
+
+	var res domain.ConsumedCode
+
+	if err := row.Scan(&res.Value, &res.Identifier, &res.ConsumedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *codeMysqlRepository) GetLastCreatedAt(ctx context.Context, identifier string) (time.Time, error) {
+	query := `SELECT MAX(created_at) FROM code WHERE identifier = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, identifier)
+
+	var createdAt sql.NullTime
+
+	if err := row.Scan(&createdAt); err != nil {
+		return time.Time{}, err
+	}
+
+	return createdAt.Time, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
@@ -18,14 +19,14 @@ func TestStoreError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("INSERT INTO code (value, identifier) VALUES (?, ?);")
+	query := regexp.QuoteMeta("INSERT INTO code (value, identifier, channel, expires_at, created_at) VALUES (?, ?, ?, ?, ?);")
 
 	mock.ExpectPrepare(query)
-	mock.ExpectExec(query).WithArgs("value", "identifier").WillReturnError(errors.New("error message"))
+	mock.ExpectExec(query).WithArgs("value", "identifier", "phone", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(errors.New("error message"))
 
 	codeMysqlRepository := NewCodeMysqlRepository(db)
 
-	err = codeMysqlRepository.Store(context.Background(), &domain.Code{Value: "value", Identifier: "identifier"})
+	err = codeMysqlRepository.Store(context.Background(), &domain.Code{Value: "value", Identifier: "identifier", Channel: "phone"})
 
 	assert.Error(t, err)
 
@@ -41,14 +42,17 @@ func TestStore(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("INSERT INTO code (value, identifier) VALUES (?, ?);")
+	createdAt := time.Now()
+	expiresAt := createdAt.Add(30 * time.Minute)
+
+	query := regexp.QuoteMeta("INSERT INTO code (value, identifier, channel, expires_at, created_at) VALUES (?, ?, ?, ?, ?);")
 
 	mock.ExpectPrepare(query)
-	mock.ExpectExec(query).WithArgs("value", "identifier").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs("value", "identifier", "phone", expiresAt, createdAt).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	codeMysqlRepository := NewCodeMysqlRepository(db)
 
-	err = codeMysqlRepository.Store(context.Background(), &domain.Code{Value: "value", Identifier: "identifier"})
+	err = codeMysqlRepository.Store(context.Background(), &domain.Code{Value: "value", Identifier: "identifier", Channel: "phone", ExpiresAt: expiresAt, CreatedAt: createdAt})
 
 	assert.NoError(t, err)
 
@@ -64,9 +68,9 @@ func TestGetByValueNotFound(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"value", "identifier"})
+	rows := sqlmock.NewRows([]string{"value", "identifier", "channel", "expires_at"})
 
-	query := regexp.QuoteMeta("SELECT value, identifier FROM code WHERE value = ?;")
+	query := regexp.QuoteMeta("SELECT value, identifier, channel, expires_at FROM code WHERE value = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -89,7 +93,7 @@ func TestGetByValueError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT value, identifier FROM code WHERE value = ?;")
+	query := regexp.QuoteMeta("SELECT value, identifier, channel, expires_at FROM code WHERE value = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -111,9 +115,11 @@ func TestGetByValue(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"value", "identifier"}).AddRow("value", "identifier")
+	expiresAt := time.Now().Add(30 * time.Minute)
+
+	rows := sqlmock.NewRows([]string{"value", "identifier", "channel", "expires_at"}).AddRow("value", "identifier", "phone", expiresAt)
 
-	query := regexp.QuoteMeta("SELECT value, identifier FROM code WHERE value = ?;")
+	query := regexp.QuoteMeta("SELECT value, identifier, channel, expires_at FROM code WHERE value = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -124,6 +130,8 @@ func TestGetByValue(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "value", code.Value)
 	assert.Equal(t, "identifier", code.Identifier)
+	assert.Equal(t, "phone", code.Channel)
+	assert.Equal(t, expiresAt, code.ExpiresAt)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Error(err)
@@ -175,3 +183,159 @@ func TestDelete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestStoreConsumedError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	consumedAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO code_consumed (value, identifier, consumed_at) VALUES (?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("value", "identifier", consumedAt).WillReturnError(errors.New("error message"))
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	err = codeMysqlRepository.StoreConsumed(context.Background(), &domain.ConsumedCode{Value: "value", Identifier: "identifier", ConsumedAt: consumedAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreConsumed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	consumedAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO code_consumed (value, identifier, consumed_at) VALUES (?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("value", "identifier", consumedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	err = codeMysqlRepository.StoreConsumed(context.Background(), &domain.ConsumedCode{Value: "value", Identifier: "identifier", ConsumedAt: consumedAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetConsumedByValueNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"value", "identifier", "consumed_at"})
+
+	query := regexp.QuoteMeta("SELECT value, identifier, consumed_at FROM code_consumed WHERE value = ? ORDER BY consumed_at DESC LIMIT 1;")
+
+	mock.ExpectQuery(query).WithArgs("value").WillReturnRows(rows)
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	consumed, err := codeMysqlRepository.GetConsumedByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+	assert.Nil(t, consumed)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetConsumedByValue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	consumedAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"value", "identifier", "consumed_at"}).AddRow("value", "identifier", consumedAt)
+
+	query := regexp.QuoteMeta("SELECT value, identifier, consumed_at FROM code_consumed WHERE value = ? ORDER BY consumed_at DESC LIMIT 1;")
+
+	mock.ExpectQuery(query).WithArgs("value").WillReturnRows(rows)
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	consumed, err := codeMysqlRepository.GetConsumedByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "value", consumed.Value)
+	assert.Equal(t, "identifier", consumed.Identifier)
+	assert.Equal(t, consumedAt, consumed.ConsumedAt)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetLastCreatedAtNone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"MAX(created_at)"}).AddRow(nil)
+
+	query := regexp.QuoteMeta("SELECT MAX(created_at) FROM code WHERE identifier = ?;")
+
+	mock.ExpectQuery(query).WithArgs("identifier").WillReturnRows(rows)
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	createdAt, err := codeMysqlRepository.GetLastCreatedAt(context.Background(), "identifier")
+
+	assert.NoError(t, err)
+	assert.True(t, createdAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetLastCreatedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	lastCreatedAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"MAX(created_at)"}).AddRow(lastCreatedAt)
+
+	query := regexp.QuoteMeta("SELECT MAX(created_at) FROM code WHERE identifier = ?;")
+
+	mock.ExpectQuery(query).WithArgs("identifier").WillReturnRows(rows)
+
+	codeMysqlRepository := NewCodeMysqlRepository(db)
+
+	createdAt, err := codeMysqlRepository.GetLastCreatedAt(context.Background(), "identifier")
+
+	assert.NoError(t, err)
+	assert.Equal(t, lastCreatedAt, createdAt)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
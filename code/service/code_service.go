@@ -16,13 +16,17 @@ func NewCodeService(cr domain.CodeRepository) *codeService {
 	return &codeService{codeRepo: cr}
 }
 
-func (cs *codeService) GenerateNewCode(ctx context.Context, identifier string, length int8, number bool, symbol bool) (*domain.Code, error) {
+func (cs *codeService) GenerateNewCode(ctx context.Context, identifier string, channel string, length int8, number bool, symbol bool, ttl time.Duration) (*domain.Code, error) {
 	rand.Seed(time.Now().UnixNano())
 	letterRunes := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 	numberRunes := []rune("1234567890")
 	symbolRunes := []rune(":?=-()/%@!")
 
-	code := &domain.Code{Identifier: identifier}
+	code := &domain.Code{Identifier: identifier, Channel: channel, CreatedAt: time.Now()}
+
+	if ttl > 0 {
+		code.ExpiresAt = code.CreatedAt.Add(ttl)
+	}
 
 	b := make([]rune, length)
 
@@ -87,6 +91,9 @@ func (cs *codeService) GenerateNewCodeFake(ctx context.Context) {
 	time.Sleep(time.Duration((8 + rand.Intn(5))) * time.Second)
 }
 
+// ValidateCode consumes a matching code, the channel is only compared when
+// the candidate carries one. An expired code is consumed as well so it can't
+// be tried again, and ErrCodeExpired is returned.
 func (cs *codeService) ValidateCode(ctx context.Context, c *domain.Code) (domain.IsValid, error) {
 	code, err := cs.codeRepo.GetByValue(ctx, c.Value)
 
@@ -94,9 +101,11 @@ func (cs *codeService) ValidateCode(ctx context.Context, c *domain.Code) (domain
 		return false, err
 	}
 
-	if code != nil && code.Identifier == c.Identifier && code.Value == c.Value {
+	if code != nil && code.Identifier == c.Identifier && code.Value == c.Value && (c.Channel == "" || code.Channel == c.Channel) {
 		if err := cs.codeRepo.DeleteByValue(ctx, c.Value); err != nil {
 			return false, err
+		} else if !code.ExpiresAt.IsZero() && time.Now().After(code.ExpiresAt) {
+			return false, domain.ErrCodeExpired
 		} else {
 			return true, nil
 		}
@@ -104,3 +113,47 @@ func (cs *codeService) ValidateCode(ctx context.Context, c *domain.Code) (domain
 		return false, nil
 	}
 }
+
+// CheckCode matches a code the same way ValidateCode does but leaves it in
+// place, for callers that consume it only once their own work succeeded.
+func (cs *codeService) CheckCode(ctx context.Context, c *domain.Code) (domain.IsValid, error) {
+	code, err := cs.codeRepo.GetByValue(ctx, c.Value)
+
+	if err != nil {
+		return false, err
+	}
+
+	if code == nil || code.Identifier != c.Identifier || code.Value != c.Value || (c.Channel != "" && code.Channel != c.Channel) {
+		return false, nil
+	}
+
+	if !code.ExpiresAt.IsZero() && time.Now().After(code.ExpiresAt) {
+		return false, domain.ErrCodeExpired
+	}
+
+	return true, nil
+}
+
+func (cs *codeService) StoreConsumed(ctx context.Context, c *domain.Code) error {
+	return cs.codeRepo.StoreConsumed(ctx, &domain.ConsumedCode{Value: c.Value, Identifier: c.Identifier, ConsumedAt: time.Now()})
+}
+
+func (cs *codeService) GetConsumed(ctx context.Context, c *domain.Code) (*domain.ConsumedCode, error) {
+	consumed, err := cs.codeRepo.GetConsumedByValue(ctx, c.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if consumed == nil || consumed.Identifier != c.Identifier {
+		return nil, nil
+	}
+
+	return consumed, nil
+}
+
+// LastIssuedAt returns when the latest pending code for the identifier was
+// generated, zero when there is none.
+func (cs *codeService) LastIssuedAt(ctx context.Context, identifier string) (time.Time, error) {
+	return cs.codeRepo.GetLastCreatedAt(ctx, identifier)
+}
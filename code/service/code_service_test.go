@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
@@ -17,7 +18,7 @@ func TestNewCodeServiceError(t *testing.T) {
 	codeRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Code")).Return(errors.New("error message"))
 
 	codeService := NewCodeService(&codeRepo)
-	_, err := codeService.GenerateNewCode(context.Background(), "identifier", 8, false, false)
+	_, err := codeService.GenerateNewCode(context.Background(), "identifier", domain.CodeChannelEmail, 8, false, false, 0)
 
 	assert.Error(t, err)
 }
@@ -28,10 +29,11 @@ func TestNewCodeService(t *testing.T) {
 	codeRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Code")).Return(nil)
 
 	codeService := NewCodeService(&codeRepo)
-	code, err := codeService.GenerateNewCode(context.Background(), "identifier", 8, false, false)
+	code, err := codeService.GenerateNewCode(context.Background(), "identifier", domain.CodeChannelEmail, 8, false, false, 0)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "identifier", code.Identifier)
+	assert.Equal(t, domain.CodeChannelEmail, code.Channel)
 	assert.Len(t, code.Value, 8)
 }
 
@@ -49,7 +51,7 @@ func TestValidateCodeGetByValueError(t *testing.T) {
 func TestValidateCodeDeleteByValueError(t *testing.T) {
 	codeRepo := mocks.MockCodeRepository{}
 
-	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", nil)
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
 	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(errors.New("error message"))
 
 	codeService := NewCodeService(&codeRepo)
@@ -61,7 +63,7 @@ func TestValidateCodeDeleteByValueError(t *testing.T) {
 func TestValidateCodeInvalidCode(t *testing.T) {
 	codeRepo := mocks.MockCodeRepository{}
 
-	codeRepo.On("GetByValue", mock.Anything, "code wrong value").Return("code value", "code identifier", nil)
+	codeRepo.On("GetByValue", mock.Anything, "code wrong value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
 
 	codeService := NewCodeService(&codeRepo)
 	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code wrong identifier", Value: "code wrong value"})
@@ -73,7 +75,7 @@ func TestValidateCodeInvalidCode(t *testing.T) {
 func TestValidateCode(t *testing.T) {
 	codeRepo := mocks.MockCodeRepository{}
 
-	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", nil)
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
 	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(nil)
 
 	codeService := NewCodeService(&codeRepo)
@@ -82,3 +84,189 @@ func TestValidateCode(t *testing.T) {
 	assert.True(t, bool(isValid))
 	assert.NoError(t, err)
 }
+
+func TestValidateCodeOtherChannel(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value", Channel: domain.CodeChannelPhone})
+
+	assert.False(t, bool(isValid))
+	assert.NoError(t, err)
+	codeRepo.AssertNotCalled(t, "DeleteByValue", mock.Anything, mock.Anything)
+}
+
+func TestValidateCodeSameChannel(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
+	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value", Channel: domain.CodeChannelEmail})
+
+	assert.True(t, bool(isValid))
+	assert.NoError(t, err)
+}
+
+func TestNewCodeServiceWithTTL(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.Code) bool {
+		return c.ExpiresAt.Equal(c.CreatedAt.Add(30*time.Minute)) && c.ExpiresAt.After(time.Now().Add(29*time.Minute)) && c.ExpiresAt.Before(time.Now().Add(31*time.Minute))
+	})).Return(nil)
+
+	codeService := NewCodeService(&codeRepo)
+	_, err := codeService.GenerateNewCode(context.Background(), "identifier", domain.CodeChannelEmail, 8, false, false, 30*time.Minute)
+
+	assert.NoError(t, err)
+	codeRepo.AssertExpectations(t)
+}
+
+func TestValidateCodeExpired(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Now().Add(-time.Minute), nil)
+	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.False(t, bool(isValid))
+	assert.ErrorIs(t, err, domain.ErrCodeExpired)
+	codeRepo.AssertCalled(t, "DeleteByValue", mock.Anything, "code value")
+}
+
+func TestValidateCodeNotExpired(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Now().Add(time.Minute), nil)
+	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.True(t, bool(isValid))
+	assert.NoError(t, err)
+}
+
+func TestValidateCodeSingleUse(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Now().Add(time.Minute), nil).Once()
+	codeRepo.On("DeleteByValue", mock.Anything, "code value").Return(nil).Once()
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return(nil, nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.True(t, bool(isValid))
+	assert.NoError(t, err)
+
+	isValid, err = codeService.ValidateCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.False(t, bool(isValid))
+	assert.NoError(t, err)
+}
+
+func TestCheckCodeKeepsCode(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.CheckCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.True(t, bool(isValid))
+	assert.NoError(t, err)
+	codeRepo.AssertNotCalled(t, "DeleteByValue", mock.Anything, mock.Anything)
+}
+
+func TestCheckCodeOtherIdentifier(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Time{}, nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.CheckCode(context.Background(), &domain.Code{Identifier: "code wrong identifier", Value: "code value"})
+
+	assert.False(t, bool(isValid))
+	assert.NoError(t, err)
+}
+
+func TestCheckCodeExpired(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetByValue", mock.Anything, "code value").Return("code value", "code identifier", domain.CodeChannelEmail, time.Now().Add(-time.Minute), nil)
+
+	codeService := NewCodeService(&codeRepo)
+	isValid, err := codeService.CheckCode(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.False(t, bool(isValid))
+	assert.ErrorIs(t, err, domain.ErrCodeExpired)
+}
+
+func TestStoreConsumed(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("StoreConsumed", mock.Anything, mock.MatchedBy(func(cc *domain.ConsumedCode) bool {
+		return cc.Value == "code value" && cc.Identifier == "code identifier" && !cc.ConsumedAt.IsZero()
+	})).Return(nil)
+
+	codeService := NewCodeService(&codeRepo)
+	err := codeService.StoreConsumed(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.NoError(t, err)
+	codeRepo.AssertExpectations(t)
+}
+
+func TestGetConsumedError(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetConsumedByValue", mock.Anything, "code value").Return(nil, errors.New("error message"))
+
+	codeService := NewCodeService(&codeRepo)
+	_, err := codeService.GetConsumed(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.Error(t, err)
+}
+
+func TestGetConsumedOtherIdentifier(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetConsumedByValue", mock.Anything, "code value").Return("code value", "other identifier", time.Now(), nil)
+
+	codeService := NewCodeService(&codeRepo)
+	consumed, err := codeService.GetConsumed(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, consumed)
+}
+
+func TestGetConsumed(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	codeRepo.On("GetConsumedByValue", mock.Anything, "code value").Return("code value", "code identifier", time.Now(), nil)
+
+	codeService := NewCodeService(&codeRepo)
+	consumed, err := codeService.GetConsumed(context.Background(), &domain.Code{Identifier: "code identifier", Value: "code value"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "code identifier", consumed.Identifier)
+}
+
+func TestLastIssuedAt(t *testing.T) {
+	codeRepo := mocks.MockCodeRepository{}
+
+	lastCreatedAt := time.Now()
+
+	codeRepo.On("GetLastCreatedAt", mock.Anything, "identifier").Return(lastCreatedAt, nil)
+
+	codeService := NewCodeService(&codeRepo)
+	issuedAt, err := codeService.LastIssuedAt(context.Background(), "identifier")
+
+	assert.NoError(t, err)
+	assert.Equal(t, lastCreatedAt, issuedAt)
+}
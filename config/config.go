@@ -14,6 +14,47 @@ type conf struct {
 	Context struct {
 		Timeout int8
 	}
+	Auth struct {
+		TokenTransport         string            `yaml:"tokenTransport"`
+		MinPasswordScore       int               `yaml:"minPasswordScore"`
+		BreachCheck            bool              `yaml:"breachCheck"`
+		PasswordHashCost       int               `yaml:"passwordHashCost"`
+		DefaultRole            string            `yaml:"defaultRole"`
+		FirstUserIsAdmin       bool              `yaml:"firstUserIsAdmin"`
+		ResetRetryGrace        int64             `yaml:"resetRetryGrace"`
+		InviteOnly             bool              `yaml:"inviteOnly"`
+		ResetCodeSingleChannel bool              `yaml:"resetCodeSingleChannel"`
+		WelcomeCredit          int64             `yaml:"welcomeCredit"`
+		SignUpHoneypot         bool              `yaml:"signUpHoneypot"`
+		LoginMinDuration       int64             `yaml:"loginMinDuration"`
+		ClientAudiences        map[string]string `yaml:"clientAudiences"`
+		TokenTTL               int64             `yaml:"tokenTTL"`
+		TermsVersion           string            `yaml:"termsVersion"`
+		RefreshTokenTTL        int64             `yaml:"refreshTokenTTL"`
+		EmailVerificationTTL   int64             `yaml:"emailVerificationTTL"`
+		ResetCodeTTL           int64             `yaml:"resetCodeTTL"`
+		ResetCodeCooldown      int64             `yaml:"resetCodeCooldown"`
+		UniquePhoneNumbers     bool              `yaml:"uniquePhoneNumbers"`
+		TwoFactor              bool              `yaml:"twoFactor"`
+		TwoFactorIssuer        string            `yaml:"twoFactorIssuer"`
+		Password               struct {
+			MinLength     int  `yaml:"minLength"`
+			RequireUpper  bool `yaml:"requireUpper"`
+			RequireLower  bool `yaml:"requireLower"`
+			RequireDigit  bool `yaml:"requireDigit"`
+			RequireSymbol bool `yaml:"requireSymbol"`
+		}
+	}
+	Product struct {
+		DefaultLocale string `yaml:"defaultLocale"`
+		MaxBulkItems  int    `yaml:"maxBulkItems"`
+	}
+	Token struct {
+		Claims        []string
+		RoleTTLs      map[string]int64 `yaml:"roleTTLs"`
+		Encrypted     bool
+		EncryptionKey string `yaml:"encryptionKey"`
+	}
 	Database struct {
 		Host string
 		Port string
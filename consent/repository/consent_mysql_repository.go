@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type consentMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewConsentMysqlRepository(conn *sql.DB) domain.ConsentRepository {
+	return &consentMysqlRepository{Conn: conn}
+}
+
+func (r *consentMysqlRepository) Store(ctx context.Context, c *domain.Consent) error {
+	query := `INSERT INTO user_consent (login, purpose, granted, source, recorded_at) VALUES (?, ?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, c.Login, c.Purpose, c.Granted, c.Source, c.RecordedAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store consent with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// GetByLogin returns the whole consent history of the login, oldest first.
+func (r *consentMysqlRepository) GetByLogin(ctx context.Context, login string) ([]domain.Consent, error) {
+	query := `SELECT login, purpose, granted, source, recorded_at FROM user_consent WHERE login = ? ORDER BY recorded_at, id;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var res []domain.Consent
+
+	for rows.Next() {
+		var c domain.Consent
+
+		if err := rows.Scan(&c.Login, &c.Purpose, &c.Granted, &c.Source, &c.RecordedAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, c)
+	}
+
+	return res, rows.Err()
+}
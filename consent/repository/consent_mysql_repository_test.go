@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	recordedAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO user_consent (login, purpose, granted, source, recorded_at) VALUES (?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", domain.ConsentPurposeMarketing, true, "signup", recordedAt).WillReturnError(errors.New("error message"))
+
+	consentMysqlRepository := NewConsentMysqlRepository(db)
+
+	err = consentMysqlRepository.Store(context.Background(), &domain.Consent{Login: "login", Purpose: domain.ConsentPurposeMarketing, Granted: true, Source: "signup", RecordedAt: recordedAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	recordedAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO user_consent (login, purpose, granted, source, recorded_at) VALUES (?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", domain.ConsentPurposeMarketing, true, "signup", recordedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	consentMysqlRepository := NewConsentMysqlRepository(db)
+
+	err = consentMysqlRepository.Store(context.Background(), &domain.Consent{Login: "login", Purpose: domain.ConsentPurposeMarketing, Granted: true, Source: "signup", RecordedAt: recordedAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByLoginError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT login, purpose, granted, source, recorded_at FROM user_consent WHERE login = ? ORDER BY recorded_at, id;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnError(errors.New("error message"))
+
+	consentMysqlRepository := NewConsentMysqlRepository(db)
+
+	_, err = consentMysqlRepository.GetByLogin(context.Background(), "login")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByLogin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	grantedAt := time.Now().Add(-time.Hour)
+	withdrawnAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"login", "purpose", "granted", "source", "recorded_at"}).
+		AddRow("login", domain.ConsentPurposeMarketing, true, "signup", grantedAt).
+		AddRow("login", domain.ConsentPurposeMarketing, false, "settings", withdrawnAt)
+
+	query := regexp.QuoteMeta("SELECT login, purpose, granted, source, recorded_at FROM user_consent WHERE login = ? ORDER BY recorded_at, id;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	consentMysqlRepository := NewConsentMysqlRepository(db)
+
+	consents, err := consentMysqlRepository.GetByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Len(t, consents, 2)
+	assert.True(t, consents[0].Granted)
+	assert.Equal(t, "signup", consents[0].Source)
+	assert.Equal(t, grantedAt, consents[0].RecordedAt)
+	assert.False(t, consents[1].Granted)
+	assert.Equal(t, "settings", consents[1].Source)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type couponMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCouponMysqlRepository(conn *sql.DB) domain.CouponRepository {
+	return &couponMysqlRepository{Conn: conn}
+}
+
+func (r *couponMysqlRepository) GetByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	query := `SELECT id, code, percent_off, amount_off_cents, expires_at, max_uses, used_count, min_order_cents FROM coupon WHERE code = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, code)
+
+	var res domain.Coupon
+	var expiresAt sql.NullTime
+
+	if err := row.Scan(&res.ID, &res.Code, &res.PercentOff, &res.AmountOffCents, &expiresAt, &res.MaxUses, &res.UsedCount, &res.MinOrderCents); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	res.ExpiresAt = expiresAt.Time
+
+	return &res, nil
+}
+
+func (r *couponMysqlRepository) Store(ctx context.Context, c *domain.Coupon) error {
+	query := `INSERT INTO coupon (code, percent_off, amount_off_cents, expires_at, max_uses, used_count, min_order_cents) VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, c.Code, c.PercentOff, c.AmountOffCents, sql.NullTime{Time: c.ExpiresAt, Valid: !c.ExpiresAt.IsZero()}, c.MaxUses, c.UsedCount, c.MinOrderCents)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store coupon with total rows affected: %d", affect)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByCodeNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "code", "percent_off", "amount_off_cents", "expires_at", "max_uses", "used_count", "min_order_cents"})
+
+	query := regexp.QuoteMeta("SELECT id, code, percent_off, amount_off_cents, expires_at, max_uses, used_count, min_order_cents FROM coupon WHERE code = ?;")
+
+	mock.ExpectQuery(query).WithArgs("OFF10").WillReturnRows(rows)
+
+	couponMysqlRepository := NewCouponMysqlRepository(db)
+
+	coupon, err := couponMysqlRepository.GetByCode(context.Background(), "OFF10")
+
+	assert.NoError(t, err)
+	assert.Nil(t, coupon)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "code", "percent_off", "amount_off_cents", "expires_at", "max_uses", "used_count", "min_order_cents"}).AddRow(1, "OFF10", 10, 0, expiresAt, 100, 7, 5000)
+
+	query := regexp.QuoteMeta("SELECT id, code, percent_off, amount_off_cents, expires_at, max_uses, used_count, min_order_cents FROM coupon WHERE code = ?;")
+
+	mock.ExpectQuery(query).WithArgs("OFF10").WillReturnRows(rows)
+
+	couponMysqlRepository := NewCouponMysqlRepository(db)
+
+	coupon, err := couponMysqlRepository.GetByCode(context.Background(), "OFF10")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Coupon{ID: 1, Code: "OFF10", PercentOff: 10, ExpiresAt: expiresAt, MaxUses: 100, UsedCount: 7, MinOrderCents: 5000}, coupon)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO coupon (code, percent_off, amount_off_cents, expires_at, max_uses, used_count, min_order_cents) VALUES (?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("OFF10", int64(10), int64(0), sqlmock.AnyArg(), int64(100), int64(0), int64(5000)).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	couponMysqlRepository := NewCouponMysqlRepository(db)
+
+	err = couponMysqlRepository.Store(context.Background(), &domain.Coupon{Code: "OFF10", PercentOff: 10, MaxUses: 100, MinOrderCents: 5000})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type couponService struct {
+	couponRepo domain.CouponRepository
+	now        func() time.Time
+}
+
+func NewCouponService(cr domain.CouponRepository) *couponService {
+	return &couponService{couponRepo: cr, now: time.Now}
+}
+
+// Discount validates the coupon against the order total and returns the
+// discount in cents. The use itself is taken when the order is stored, with a
+// guarded increment so concurrent orders can not go over the max uses.
+func (cs *couponService) Discount(ctx context.Context, code string, totalCents int64) (int64, error) {
+	coupon, err := cs.couponRepo.GetByCode(ctx, code)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if coupon == nil {
+		return 0, fmt.Errorf("%w: coupon %s not found", domain.ErrInvalidCoupon, code)
+	}
+
+	if !coupon.ExpiresAt.IsZero() && !cs.now().Before(coupon.ExpiresAt) {
+		return 0, fmt.Errorf("%w: coupon %s expired at %s", domain.ErrInvalidCoupon, code, coupon.ExpiresAt)
+	}
+
+	if coupon.MaxUses > 0 && coupon.UsedCount >= coupon.MaxUses {
+		return 0, fmt.Errorf("%w: coupon %s reached its %d uses", domain.ErrInvalidCoupon, code, coupon.MaxUses)
+	}
+
+	if totalCents < coupon.MinOrderCents {
+		return 0, fmt.Errorf("%w: coupon %s requires orders of at least %d cents, got %d", domain.ErrInvalidCoupon, code, coupon.MinOrderCents, totalCents)
+	}
+
+	discount := coupon.AmountOffCents
+
+	if coupon.PercentOff > 0 {
+		discount = totalCents * coupon.PercentOff / 100
+	}
+
+	if discount > totalCents {
+		discount = totalCents
+	}
+
+	return discount, nil
+}
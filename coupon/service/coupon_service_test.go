@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestCouponService(cr domain.CouponRepository) *couponService {
+	cs := NewCouponService(cr)
+	cs.now = func() time.Time { return now }
+	return cs
+}
+
+func TestDiscountNotFound(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "OFF10").Return(nil, nil)
+
+	_, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "OFF10", 5000)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+}
+
+func TestDiscountExpired(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "OFF10").Return("OFF10", 10, 0, now.Add(-time.Minute), 0, 0, 0, nil)
+
+	_, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "OFF10", 5000)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+}
+
+func TestDiscountMaxUsesReached(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "OFF10").Return("OFF10", 10, 0, time.Time{}, 3, 3, 0, nil)
+
+	_, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "OFF10", 5000)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+}
+
+func TestDiscountBelowMinimumOrder(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "OFF10").Return("OFF10", 10, 0, now.Add(time.Hour), 0, 0, 10000, nil)
+
+	_, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "OFF10", 9999)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+}
+
+func TestDiscountPercentOff(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "OFF10").Return("OFF10", 10, 0, now.Add(time.Hour), 3, 2, 10000, nil)
+
+	discount, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "OFF10", 10000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), discount)
+	mockCouponRepo.AssertExpectations(t)
+}
+
+func TestDiscountAmountOffCappedAtTotal(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("GetByCode", mock.Anything, "FIVE").Return("FIVE", 0, 500, time.Time{}, 0, 0, 0, nil)
+
+	discount, err := newTestCouponService(mockCouponRepo).Discount(context.Background(), "FIVE", 300)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), discount)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type couponUseCase struct {
+	couponRepo domain.CouponRepository
+}
+
+func NewCouponUseCase(cr domain.CouponRepository) domain.CouponUseCase {
+	return &couponUseCase{couponRepo: cr}
+}
+
+// Create stores a coupon giving either a percent or a fixed amount off.
+func (cu *couponUseCase) Create(ctx context.Context, c *domain.Coupon) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	c.Code = strings.TrimSpace(c.Code)
+
+	if c.Code == "" {
+		return fmt.Errorf("coupon code can not be empty")
+	}
+
+	if (c.PercentOff > 0) == (c.AmountOffCents > 0) {
+		return fmt.Errorf("coupon %s must have either a percent or an amount off", c.Code)
+	}
+
+	if c.PercentOff < 0 || c.PercentOff > 100 || c.AmountOffCents < 0 {
+		return fmt.Errorf("coupon %s discount is out of range", c.Code)
+	}
+
+	if c.MaxUses < 0 || c.MinOrderCents < 0 {
+		return fmt.Errorf("coupon %s limits can not be negative", c.Code)
+	}
+
+	c.UsedCount = 0
+
+	return cu.couponRepo.Store(ctx, c)
+}
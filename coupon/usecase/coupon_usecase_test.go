@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var adminCtx = domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "admin", Role: domain.RoleAdmin})
+
+func TestCreateNotAdmin(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	ctx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer", Role: domain.RoleCustomer})
+
+	err := NewCouponUseCase(mockCouponRepo).Create(ctx, &domain.Coupon{Code: "OFF10", PercentOff: 10})
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockCouponRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateWithoutDiscount(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	err := NewCouponUseCase(mockCouponRepo).Create(adminCtx, &domain.Coupon{Code: "OFF10"})
+
+	assert.Error(t, err)
+	mockCouponRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateWithBothDiscounts(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	err := NewCouponUseCase(mockCouponRepo).Create(adminCtx, &domain.Coupon{Code: "OFF10", PercentOff: 10, AmountOffCents: 500})
+
+	assert.Error(t, err)
+	mockCouponRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreatePercentOutOfRange(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	err := NewCouponUseCase(mockCouponRepo).Create(adminCtx, &domain.Coupon{Code: "OFF110", PercentOff: 110})
+
+	assert.Error(t, err)
+	mockCouponRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreate(t *testing.T) {
+	mockCouponRepo := new(mocks.MockCouponRepository)
+
+	mockCouponRepo.On("Store", mock.Anything, &domain.Coupon{Code: "OFF10", PercentOff: 10, MaxUses: 100}).Return(nil)
+
+	err := NewCouponUseCase(mockCouponRepo).Create(adminCtx, &domain.Coupon{Code: " OFF10 ", PercentOff: 10, MaxUses: 100, UsedCount: 7})
+
+	assert.NoError(t, err)
+	mockCouponRepo.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type creditMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCreditMysqlRepository(conn *sql.DB) domain.CreditRepository {
+	return &creditMysqlRepository{Conn: conn}
+}
+
+func (r *creditMysqlRepository) StoreTransaction(ctx context.Context, t *domain.CreditTransaction) error {
+	query := `INSERT INTO credit_transaction (user_uuid, amount, reason, created_at) VALUES (?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, t.UserUUID, t.Amount, t.Reason, t.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store credit transaction with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *creditMysqlRepository) GetBalance(ctx context.Context, userUUID string) (int64, error) {
+	query := `SELECT COALESCE(SUM(amount), 0) FROM credit_transaction WHERE user_uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, userUUID)
+
+	var balance int64
+
+	if err := row.Scan(&balance); err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
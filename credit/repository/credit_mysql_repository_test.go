@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreTransactionError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	createdAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO credit_transaction (user_uuid, amount, reason, created_at) VALUES (?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("user uuid", int64(1000), domain.CreditReasonWelcomeBonus, createdAt).WillReturnError(errors.New("error message"))
+
+	creditMysqlRepository := NewCreditMysqlRepository(db)
+
+	err = creditMysqlRepository.StoreTransaction(context.Background(), &domain.CreditTransaction{UserUUID: "user uuid", Amount: 1000, Reason: domain.CreditReasonWelcomeBonus, CreatedAt: createdAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	createdAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO credit_transaction (user_uuid, amount, reason, created_at) VALUES (?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("user uuid", int64(1000), domain.CreditReasonWelcomeBonus, createdAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	creditMysqlRepository := NewCreditMysqlRepository(db)
+
+	err = creditMysqlRepository.StoreTransaction(context.Background(), &domain.CreditTransaction{UserUUID: "user uuid", Amount: 1000, Reason: domain.CreditReasonWelcomeBonus, CreatedAt: createdAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetBalanceError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM credit_transaction WHERE user_uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("user uuid").WillReturnError(errors.New("error message"))
+
+	creditMysqlRepository := NewCreditMysqlRepository(db)
+
+	_, err = creditMysqlRepository.GetBalance(context.Background(), "user uuid")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"balance"}).AddRow(1500)
+
+	query := regexp.QuoteMeta("SELECT COALESCE(SUM(amount), 0) FROM credit_transaction WHERE user_uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("user uuid").WillReturnRows(rows)
+
+	creditMysqlRepository := NewCreditMysqlRepository(db)
+
+	balance, err := creditMysqlRepository.GetBalance(context.Background(), "user uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), balance)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+type Address struct {
+	ID      int64
+	UUID    string `json:"uuid"`
+	Login   string `json:"-"`
+	Label   string `json:"label"`
+	Street  string `json:"street"`
+	Number  string `json:"number"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	ZipCode string `json:"zipcode"`
+	Country string `json:"country"`
+	Default bool   `json:"default"`
+}
+
+type AddressUseCase interface {
+	Add(ctx context.Context, login string, a *Address) error
+	List(ctx context.Context, login string) ([]Address, error)
+	Update(ctx context.Context, login string, a *Address) error
+	Delete(ctx context.Context, login string, uuid string) error
+	SetDefault(ctx context.Context, login string, uuid string) error
+}
+
+type AddressRepository interface {
+	Add(ctx context.Context, login string, a *Address) error
+	List(ctx context.Context, login string) ([]Address, error)
+	Update(ctx context.Context, login string, a *Address) error
+	Delete(ctx context.Context, login string, uuid string) error
+	SetDefault(ctx context.Context, login string, uuid string) error
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+const InviteIdentifierPrefix = "invite:"
+
+type AdminUseCase interface {
+	CreateInvite(ctx context.Context, email string) (*Code, error)
+}
@@ -2,32 +2,115 @@ package domain
 
 import "context"
 
+const (
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+)
+
 type Auth struct {
 	ID       int64
 	UUID     string `json:"uuid"`
 	Login    string `json:"login"`
 	Password string `json:"password"`
+	Role     string `json:"-"`
+	Verified bool   `json:"-"`
+}
+
+type TokenTransport string
+
+const (
+	TokenTransportBearer TokenTransport = "bearer"
+	TokenTransportCookie TokenTransport = "cookie"
+)
+
+type AuthConfig struct {
+	TokenTransport              TokenTransport
+	DefaultRole                 string
+	FirstUserIsAdmin            bool
+	ResetRetryGraceSeconds      int64
+	InviteOnly                  bool
+	ResetCodeSingleChannel      bool
+	WelcomeCreditAmount         int64
+	SignUpHoneypot              bool
+	LoginMinDurationMillis      int64
+	ClientAudiences             map[string]string
+	TokenTTLMinutes             int64
+	RoleTTLMinutes              map[string]int64
+	TermsVersion                string
+	RefreshTokenTTLMinutes      int64
+	EmailVerificationTTLMinutes int64
+	ResetCodeTTLMinutes         int64
+	ResetCodeCooldownSeconds    int64
+	UniquePhoneNumbers          bool
+	TwoFactor                   bool
+	TwoFactorIssuer             string
+}
+
+type LoginInput struct {
+	Client string
+}
+
+type SignUpInput struct {
+	Client       string
+	InviteToken  string
+	Honeypot     string
+	TermsVersion string
+}
+
+// AuthResult carries only TwoFactorToken when the login still needs a two factor code.
+type AuthResult struct {
+	TokenPair
+	Transport      TokenTransport
+	MaxAge         int64
+	RefreshMaxAge  int64
+	TwoFactorToken Token
 }
 
 type AuthUseCase interface {
-	Login(ctx context.Context, a *Auth) (Token, error)
-	SignUp(ctx context.Context, a *Auth, u *User) (Token, error)
-	ForgotPassCode(ctx context.Context, login string) error
-	ForgotPassReset(ctx context.Context, code *Code, newPass string) (Token, error)
+	Login(ctx context.Context, a *Auth, in LoginInput) (*AuthResult, error)
+	SignUp(ctx context.Context, a *Auth, u *User, in SignUpInput) (*AuthResult, error)
+	ForgotPassCode(ctx context.Context, login string, channel string) error
+	ForgotPassReset(ctx context.Context, code *Code, newPass string, client string) (*AuthResult, error)
+	Logout(ctx context.Context, t Token, refresh Token) error
+	RefreshToken(ctx context.Context, refresh Token) (*AuthResult, error)
+	VerifyEmail(ctx context.Context, login string, code string) error
+	ChangePassword(ctx context.Context, login string, oldPassword string, newPassword string) error
+	VerifyToken(ctx context.Context, t Token) (TokenInfo, error)
+	EnableTwoFactor(ctx context.Context, login string) (string, string, error)
+	VerifyTwoFactor(ctx context.Context, login string, code string) error
+	LoginTwoFactor(ctx context.Context, twoFactorToken Token, code string) (*AuthResult, error)
 }
 
 type AuthService interface {
-	EncodePass(ctx context.Context, pass string) string
+	EncodePass(ctx context.Context, pass string) (string, error)
 	PassIsEqualHashedPass(ctx context.Context, pass string, hashedPass string) bool
+	NeedsRehash(ctx context.Context, hashedPass string) bool
 }
 
 type AuthRepository interface {
 	GetByLogin(ctx context.Context, login string) (*Auth, error)
 	StoreWithUser(ctx context.Context, a *Auth, u *User) error
 	Update(ctx context.Context, a *Auth) error
+	Count(ctx context.Context) (int64, error)
+	MarkVerified(ctx context.Context, login string) error
+	UpdatePassword(ctx context.Context, login string, password string) error
+}
+
+type PasswordPolicy struct {
+	MinLength       int
+	RequireUpper    bool
+	RequireLower    bool
+	RequireDigit    bool
+	RequireSymbol   bool
+	MinEntropyScore int
+}
+
+type BreachChecker interface {
+	IsBreached(ctx context.Context, pass string) (bool, error)
 }
 
 type AuthValidator interface {
 	Validate(ctx context.Context, a *Auth) (IsValid, Message)
 	ValidateLogin(ctx context.Context, login string) (IsValid, Message)
+	ValidatePassword(ctx context.Context, password string) (IsValid, Message)
 }
@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+type CartItem struct {
+	ProductUUID string `json:"productUuid"`
+	Quantity    int64  `json:"quantity"`
+	UnitPrice   int64  `json:"unitPrice"`
+}
+
+type Cart struct {
+	Login string     `json:"-"`
+	Items []CartItem `json:"items"`
+	Total int64      `json:"total"`
+}
+
+type CartUseCase interface {
+	AddItem(ctx context.Context, login string, productUUID string, quantity int64) error
+	RemoveItem(ctx context.Context, login string, productUUID string) error
+	UpdateQuantity(ctx context.Context, login string, productUUID string, quantity int64) error
+	GetCart(ctx context.Context, login string) (*Cart, error)
+	Clear(ctx context.Context, login string) error
+}
+
+type CartRepository interface {
+	List(ctx context.Context, login string) ([]CartItem, error)
+	SetItem(ctx context.Context, login string, item *CartItem) error
+	RemoveItem(ctx context.Context, login string, productUUID string) error
+	Clear(ctx context.Context, login string) error
+}
@@ -1,20 +1,44 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+const (
+	CodeChannelPhone = "phone"
+	CodeChannelEmail = "email"
+)
 
 type Code struct {
 	Value      string
 	Identifier string
+	Channel    string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
+
+type ConsumedCode struct {
+	Value      string
+	Identifier string
+	ConsumedAt time.Time
 }
 
 type CodeService interface {
-	GenerateNewCode(ctx context.Context, identifier string, length int8, number bool, symbol bool) (*Code, error)
+	GenerateNewCode(ctx context.Context, identifier string, channel string, length int8, number bool, symbol bool, ttl time.Duration) (*Code, error)
 	GenerateNewCodeFake(ctx context.Context)
 	ValidateCode(ctx context.Context, c *Code) (IsValid, error)
+	CheckCode(ctx context.Context, c *Code) (IsValid, error)
+	StoreConsumed(ctx context.Context, c *Code) error
+	GetConsumed(ctx context.Context, c *Code) (*ConsumedCode, error)
+	LastIssuedAt(ctx context.Context, identifier string) (time.Time, error)
 }
 
 type CodeRepository interface {
 	Store(ctx context.Context, code *Code) error
 	GetByValue(ctx context.Context, value string) (*Code, error)
 	DeleteByValue(ctx context.Context, value string) error
+	StoreConsumed(ctx context.Context, cc *ConsumedCode) error
+	GetConsumedByValue(ctx context.Context, value string) (*ConsumedCode, error)
+	GetLastCreatedAt(ctx context.Context, identifier string) (time.Time, error)
 }
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const ConsentPurposeMarketing = "marketing"
+
+type Consent struct {
+	Login      string    `json:"-"`
+	Purpose    string    `json:"purpose"`
+	Granted    bool      `json:"granted"`
+	Source     string    `json:"source"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type ConsentRepository interface {
+	Store(ctx context.Context, c *Consent) error
+	GetByLogin(ctx context.Context, login string) ([]Consent, error)
+}
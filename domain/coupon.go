@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Coupon struct {
+	ID             int64
+	Code           string    `json:"code"`
+	PercentOff     int64     `json:"percentOff"`
+	AmountOffCents int64     `json:"amountOffCents"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	MaxUses        int64     `json:"maxUses"`
+	UsedCount      int64     `json:"usedCount"`
+	MinOrderCents  int64     `json:"minOrderCents"`
+}
+
+type CouponUseCase interface {
+	Create(ctx context.Context, c *Coupon) error
+}
+
+type CouponService interface {
+	Discount(ctx context.Context, code string, totalCents int64) (int64, error)
+}
+
+type CouponRepository interface {
+	GetByCode(ctx context.Context, code string) (*Coupon, error)
+	Store(ctx context.Context, c *Coupon) error
+}
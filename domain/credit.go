@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const CreditReasonWelcomeBonus = "welcome_bonus"
+
+type CreditTransaction struct {
+	ID        int64
+	UserUUID  string    `json:"-"`
+	Amount    int64     `json:"amount"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreditRepository interface {
+	StoreTransaction(ctx context.Context, t *CreditTransaction) error
+	GetBalance(ctx context.Context, userUUID string) (int64, error)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "errors"
+
+var (
+	ErrLoginTaken         = errors.New("login already taken")
+	ErrEmailTaken         = errors.New("email already taken")
+	ErrPhoneTaken         = errors.New("phone number already taken")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrPasswordUnchanged  = errors.New("new password must differ from the current one")
+	ErrWeakPassword       = errors.New("password does not meet the policy")
+	ErrCodeExpired        = errors.New("code expired")
+	ErrTooManyRequests    = errors.New("too many requests")
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrEmptyCart          = errors.New("cart is empty")
+	ErrInvalidCoupon      = errors.New("invalid coupon")
+	ErrNotPurchased       = errors.New("product not purchased")
+	ErrAlreadyReviewed    = errors.New("product already reviewed")
+	ErrForbidden          = errors.New("forbidden")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrTooManyItems       = errors.New("too many items")
+	ErrInvalidTwoFactor   = errors.New("invalid two factor code")
+	ErrUnknownClient      = errors.New("client not allowed")
+	ErrOrderNotShippable  = errors.New("order not shippable")
+)
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAddressRepository struct {
+	mock.Mock
+}
+
+func (mar *MockAddressRepository) Add(ctx context.Context, login string, a *domain.Address) error {
+	args := mar.Called(ctx, login, a)
+	return args.Error(0)
+}
+
+func (mar *MockAddressRepository) List(ctx context.Context, login string) ([]domain.Address, error) {
+	args := mar.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Address), args.Error(1)
+}
+
+func (mar *MockAddressRepository) Update(ctx context.Context, login string, a *domain.Address) error {
+	args := mar.Called(ctx, login, a)
+	return args.Error(0)
+}
+
+func (mar *MockAddressRepository) Delete(ctx context.Context, login string, uuid string) error {
+	args := mar.Called(ctx, login, uuid)
+	return args.Error(0)
+}
+
+func (mar *MockAddressRepository) SetDefault(ctx context.Context, login string, uuid string) error {
+	args := mar.Called(ctx, login, uuid)
+	return args.Error(0)
+}
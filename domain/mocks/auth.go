@@ -11,24 +11,79 @@ type MockAuthUsecase struct {
 	mock.Mock
 }
 
-func (m *MockAuthUsecase) Login(ctx context.Context, a *domain.Auth) (domain.Token, error) {
-	args := m.Called(ctx, a)
-	return domain.Token(args.String(0)), args.Error(1)
+func (m *MockAuthUsecase) Login(ctx context.Context, a *domain.Auth, in domain.LoginInput) (*domain.AuthResult, error) {
+	args := m.Called(ctx, a, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.AuthResult{TokenPair: domain.TokenPair{Token: domain.Token(args.String(0))}, Transport: domain.TokenTransport(args.String(1)), MaxAge: int64(args.Int(2))}, args.Error(3)
+}
+
+func (m *MockAuthUsecase) SignUp(ctx context.Context, a *domain.Auth, u *domain.User, in domain.SignUpInput) (*domain.AuthResult, error) {
+	args := m.Called(ctx, a, u, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.AuthResult{TokenPair: domain.TokenPair{Token: domain.Token(args.String(0))}, Transport: domain.TokenTransport(args.String(1)), MaxAge: int64(args.Int(2))}, args.Error(3)
+}
+
+func (m *MockAuthUsecase) ForgotPassCode(ctx context.Context, login string, channel string) error {
+	args := m.Called(ctx, login, channel)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ForgotPassReset(ctx context.Context, code *domain.Code, newPass string, client string) (*domain.AuthResult, error) {
+	args := m.Called(ctx, code, newPass, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.AuthResult{TokenPair: domain.TokenPair{Token: domain.Token(args.String(0))}, Transport: domain.TokenTransport(args.String(1)), MaxAge: int64(args.Int(2))}, args.Error(3)
+}
+
+func (m *MockAuthUsecase) Logout(ctx context.Context, t domain.Token, refresh domain.Token) error {
+	args := m.Called(ctx, t, refresh)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) VerifyEmail(ctx context.Context, login string, code string) error {
+	args := m.Called(ctx, login, code)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ChangePassword(ctx context.Context, login string, oldPassword string, newPassword string) error {
+	args := m.Called(ctx, login, oldPassword, newPassword)
+	return args.Error(0)
 }
 
-func (m *MockAuthUsecase) SignUp(ctx context.Context, a *domain.Auth, u *domain.User) (domain.Token, error) {
-	args := m.Called(ctx, a, u)
-	return domain.Token(args.String(0)), args.Error(1)
+func (m *MockAuthUsecase) VerifyToken(ctx context.Context, t domain.Token) (domain.TokenInfo, error) {
+	args := m.Called(ctx, t)
+	return domain.TokenInfo{Info: args.String(0), Role: args.String(1)}, args.Error(2)
 }
 
-func (m *MockAuthUsecase) ForgotPassCode(ctx context.Context, login string) error {
+func (m *MockAuthUsecase) EnableTwoFactor(ctx context.Context, login string) (string, string, error) {
 	args := m.Called(ctx, login)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthUsecase) VerifyTwoFactor(ctx context.Context, login string, code string) error {
+	args := m.Called(ctx, login, code)
 	return args.Error(0)
 }
 
-func (m *MockAuthUsecase) ForgotPassReset(ctx context.Context, code *domain.Code, newPass string) (domain.Token, error) {
-	args := m.Called(ctx, code, newPass)
-	return domain.Token(args.String(0)), args.Error(1)
+func (m *MockAuthUsecase) LoginTwoFactor(ctx context.Context, twoFactorToken domain.Token, code string) (*domain.AuthResult, error) {
+	args := m.Called(ctx, twoFactorToken, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.AuthResult{TokenPair: domain.TokenPair{Token: domain.Token(args.String(0))}, Transport: domain.TokenTransport(args.String(1)), MaxAge: int64(args.Int(2))}, args.Error(3)
+}
+
+func (m *MockAuthUsecase) RefreshToken(ctx context.Context, refresh domain.Token) (*domain.AuthResult, error) {
+	args := m.Called(ctx, refresh)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.AuthResult{TokenPair: domain.TokenPair{Token: domain.Token(args.String(0)), RefreshToken: domain.Token(args.String(1))}, Transport: domain.TokenTransport(args.String(2)), MaxAge: int64(args.Int(3))}, args.Error(4)
 }
 
 type MockAuthValidator struct {
@@ -45,13 +100,18 @@ func (mav *MockAuthValidator) ValidateLogin(ctx context.Context, login string) (
 	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
 }
 
+func (mav *MockAuthValidator) ValidatePassword(ctx context.Context, password string) (domain.IsValid, domain.Message) {
+	args := mav.Called(ctx, password)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
 type MockAuthService struct {
 	mock.Mock
 }
 
-func (mas *MockAuthService) EncodePass(ctx context.Context, pass string) string {
+func (mas *MockAuthService) EncodePass(ctx context.Context, pass string) (string, error) {
 	args := mas.Called(ctx, pass)
-	return args.String(0)
+	return args.String(0), args.Error(1)
 }
 
 func (mas *MockAuthService) PassIsEqualHashedPass(ctx context.Context, pass string, hashedPass string) bool {
@@ -59,6 +119,11 @@ func (mas *MockAuthService) PassIsEqualHashedPass(ctx context.Context, pass stri
 	return args.Bool(0)
 }
 
+func (mas *MockAuthService) NeedsRehash(ctx context.Context, hashedPass string) bool {
+	args := mas.Called(ctx, hashedPass)
+	return args.Bool(0)
+}
+
 type MockAuthRepository struct {
 	mock.Mock
 }
@@ -68,7 +133,7 @@ func (mar *MockAuthRepository) GetByLogin(ctx context.Context, login string) (*d
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Auth{ID: int64(args.Int(0)), UUID: args.String(1), Login: args.String(2), Password: args.String(3)}, args.Error(4)
+	return &domain.Auth{ID: int64(args.Int(0)), UUID: args.String(1), Login: args.String(2), Password: args.String(3), Role: args.String(4), Verified: args.Bool(5)}, args.Error(6)
 }
 
 func (mar *MockAuthRepository) StoreWithUser(ctx context.Context, a *domain.Auth, u *domain.User) error {
@@ -80,3 +145,27 @@ func (mar *MockAuthRepository) Update(ctx context.Context, a *domain.Auth) error
 	args := mar.Called(ctx, a)
 	return args.Error(0)
 }
+
+func (mar *MockAuthRepository) Count(ctx context.Context) (int64, error) {
+	args := mar.Called(ctx)
+	return int64(args.Int(0)), args.Error(1)
+}
+
+func (mar *MockAuthRepository) MarkVerified(ctx context.Context, login string) error {
+	args := mar.Called(ctx, login)
+	return args.Error(0)
+}
+
+func (mar *MockAuthRepository) UpdatePassword(ctx context.Context, login string, password string) error {
+	args := mar.Called(ctx, login, password)
+	return args.Error(0)
+}
+
+type MockBreachChecker struct {
+	mock.Mock
+}
+
+func (mbc *MockBreachChecker) IsBreached(ctx context.Context, pass string) (bool, error) {
+	args := mbc.Called(ctx, pass)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCartRepository) List(ctx context.Context, login string) ([]domain.CartItem, error) {
+	args := mcr.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CartItem), args.Error(1)
+}
+
+func (mcr *MockCartRepository) SetItem(ctx context.Context, login string, item *domain.CartItem) error {
+	args := mcr.Called(ctx, login, item)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) RemoveItem(ctx context.Context, login string, productUUID string) error {
+	args := mcr.Called(ctx, login, productUUID)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) Clear(ctx context.Context, login string) error {
+	args := mcr.Called(ctx, login)
+	return args.Error(0)
+}
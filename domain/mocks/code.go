@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/stretchr/testify/mock"
@@ -11,12 +12,12 @@ type MockCodeService struct {
 	mock.Mock
 }
 
-func (mcs *MockCodeService) GenerateNewCode(ctx context.Context, identifier string, length int8, number bool, symbol bool) (*domain.Code, error) {
-	args := mcs.Called(ctx, identifier, length, number, symbol)
+func (mcs *MockCodeService) GenerateNewCode(ctx context.Context, identifier string, channel string, length int8, number bool, symbol bool, ttl time.Duration) (*domain.Code, error) {
+	args := mcs.Called(ctx, identifier, channel, length, number, symbol, ttl)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Code{Value: args.String(0), Identifier: args.String(1)}, args.Error(2)
+	return &domain.Code{Value: args.String(0), Identifier: args.String(1), Channel: args.String(2)}, args.Error(3)
 }
 
 func (mcs *MockCodeService) GenerateNewCodeFake(ctx context.Context) {}
@@ -26,6 +27,29 @@ func (mcs *MockCodeService) ValidateCode(ctx context.Context, c *domain.Code) (d
 	return domain.IsValid(args.Bool(0)), args.Error(1)
 }
 
+func (mcs *MockCodeService) CheckCode(ctx context.Context, c *domain.Code) (domain.IsValid, error) {
+	args := mcs.Called(ctx, c)
+	return domain.IsValid(args.Bool(0)), args.Error(1)
+}
+
+func (mcs *MockCodeService) StoreConsumed(ctx context.Context, c *domain.Code) error {
+	args := mcs.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcs *MockCodeService) GetConsumed(ctx context.Context, c *domain.Code) (*domain.ConsumedCode, error) {
+	args := mcs.Called(ctx, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ConsumedCode{Value: args.String(0), Identifier: args.String(1), ConsumedAt: args.Get(2).(time.Time)}, args.Error(3)
+}
+
+func (mcs *MockCodeService) LastIssuedAt(ctx context.Context, identifier string) (time.Time, error) {
+	args := mcs.Called(ctx, identifier)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
 type MockCodeRepository struct {
 	mock.Mock
 }
@@ -40,10 +64,28 @@ func (mcr *MockCodeRepository) GetByValue(ctx context.Context, value string) (*d
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Code{Value: args.String(0), Identifier: args.String(1)}, args.Error(2)
+	return &domain.Code{Value: args.String(0), Identifier: args.String(1), Channel: args.String(2), ExpiresAt: args.Get(3).(time.Time)}, args.Error(4)
 }
 
 func (mcr *MockCodeRepository) DeleteByValue(ctx context.Context, value string) error {
 	args := mcr.Called(ctx, value)
 	return args.Error(0)
 }
+
+func (mcr *MockCodeRepository) StoreConsumed(ctx context.Context, cc *domain.ConsumedCode) error {
+	args := mcr.Called(ctx, cc)
+	return args.Error(0)
+}
+
+func (mcr *MockCodeRepository) GetConsumedByValue(ctx context.Context, value string) (*domain.ConsumedCode, error) {
+	args := mcr.Called(ctx, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ConsumedCode{Value: args.String(0), Identifier: args.String(1), ConsumedAt: args.Get(2).(time.Time)}, args.Error(3)
+}
+
+func (mcr *MockCodeRepository) GetLastCreatedAt(ctx context.Context, identifier string) (time.Time, error) {
+	args := mcr.Called(ctx, identifier)
+	return args.Get(0).(time.Time), args.Error(1)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockConsentRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockConsentRepository) Store(ctx context.Context, c *domain.Consent) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcr *MockConsentRepository) GetByLogin(ctx context.Context, login string) ([]domain.Consent, error) {
+	args := mcr.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Consent), args.Error(1)
+}
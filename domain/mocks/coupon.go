@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCouponService struct {
+	mock.Mock
+}
+
+func (mcs *MockCouponService) Discount(ctx context.Context, code string, totalCents int64) (int64, error) {
+	args := mcs.Called(ctx, code, totalCents)
+	return int64(args.Int(0)), args.Error(1)
+}
+
+type MockCouponRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCouponRepository) GetByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	args := mcr.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.Coupon{Code: args.String(0), PercentOff: int64(args.Int(1)), AmountOffCents: int64(args.Int(2)), ExpiresAt: args.Get(3).(time.Time), MaxUses: int64(args.Int(4)), UsedCount: int64(args.Int(5)), MinOrderCents: int64(args.Int(6))}, args.Error(7)
+}
+
+func (mcr *MockCouponRepository) Store(ctx context.Context, c *domain.Coupon) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
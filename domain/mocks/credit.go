@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCreditRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCreditRepository) StoreTransaction(ctx context.Context, t *domain.CreditTransaction) error {
+	args := mcr.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (mcr *MockCreditRepository) GetBalance(ctx context.Context, userUUID string) (int64, error) {
+	args := mcr.Called(ctx, userUUID)
+	return int64(args.Int(0)), args.Error(1)
+}
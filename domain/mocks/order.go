@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (mor *MockOrderRepository) StoreFromCart(ctx context.Context, o *domain.Order) error {
+	args := mor.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) HasPurchased(ctx context.Context, login string, productUUID string) (bool, error) {
+	args := mor.Called(ctx, login, productUUID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mor *MockOrderRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Order, error) {
+	args := mor.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+func (mor *MockOrderRepository) MarkPaid(ctx context.Context, uuid string) error {
+	args := mor.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) AttachTracking(ctx context.Context, uuid string, carrier string, trackingNumber string, shippedBy string) error {
+	args := mor.Called(ctx, uuid, carrier, trackingNumber, shippedBy)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) UpdateTracking(ctx context.Context, uuid string, trackingStatus string, status string) error {
+	args := mor.Called(ctx, uuid, trackingStatus, status)
+	return args.Error(0)
+}
+
+type MockCarrierService struct {
+	mock.Mock
+}
+
+func (mcs *MockCarrierService) TrackingStatus(ctx context.Context, trackingNumber string) (string, error) {
+	args := mcs.Called(ctx, trackingNumber)
+	return args.String(0), args.Error(1)
+}
@@ -16,7 +16,43 @@ func (mpu *MockProductUsecase) Get(ctx context.Context, uuid string) (*domain.Pr
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}}, args.Error(9)
+	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}, Price: int64(args.Int(9)), SKU: args.String(10), StockQuantity: int64(args.Int(11)), Active: args.Bool(12)}, args.Error(13)
+}
+
+func (mpu *MockProductUsecase) Create(ctx context.Context, p *domain.Product) error {
+	args := mpu.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) Import(ctx context.Context, products []domain.Product) error {
+	args := mpu.Called(ctx, products)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) List(ctx context.Context, p domain.Pagination) (*domain.ProductPage, error) {
+	args := mpu.Called(ctx, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ProductPage{Items: args.Get(0).([]domain.Product), Total: int64(args.Int(1))}, args.Error(2)
+}
+
+func (mpu *MockProductUsecase) Search(ctx context.Context, query string, categoryID *string, p domain.Pagination) (*domain.ProductPage, error) {
+	args := mpu.Called(ctx, query, categoryID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ProductPage{Items: args.Get(0).([]domain.Product), Total: int64(args.Int(1))}, args.Error(2)
+}
+
+func (mpu *MockProductUsecase) Update(ctx context.Context, p *domain.Product) error {
+	args := mpu.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) Deactivate(ctx context.Context, uuid string) error {
+	args := mpu.Called(ctx, uuid)
+	return args.Error(0)
 }
 
 type MockProductRepository struct {
@@ -28,5 +64,49 @@ func (mpr *MockProductRepository) GetByUUID(ctx context.Context, uuid string) (*
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}}, args.Error(9)
+	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}, Price: int64(args.Int(9)), SKU: args.String(10), StockQuantity: int64(args.Int(11)), Active: args.Bool(12)}, args.Error(13)
+}
+
+func (mpr *MockProductRepository) Store(ctx context.Context, p *domain.Product) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) StoreBatch(ctx context.Context, products []domain.Product) error {
+	args := mpr.Called(ctx, products)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) List(ctx context.Context, p domain.Pagination) (*domain.ProductPage, error) {
+	args := mpr.Called(ctx, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ProductPage{Items: args.Get(0).([]domain.Product), Total: int64(args.Int(1))}, args.Error(2)
+}
+
+func (mpr *MockProductRepository) Search(ctx context.Context, query string, categoryID *string, p domain.Pagination) (*domain.ProductPage, error) {
+	args := mpr.Called(ctx, query, categoryID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ProductPage{Items: args.Get(0).([]domain.Product), Total: int64(args.Int(1))}, args.Error(2)
+}
+
+func (mpr *MockProductRepository) Update(ctx context.Context, p *domain.Product) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) Deactivate(ctx context.Context, uuid string) error {
+	args := mpr.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) GetTranslation(ctx context.Context, uuid string, locale string) (*domain.ProductTranslation, error) {
+	args := mpr.Called(ctx, uuid, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ProductTranslation{Locale: args.String(0), Name: args.String(1), Detail: args.String(2)}, args.Error(3)
 }
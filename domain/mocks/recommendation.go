@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRecommendationRepository) CoPurchaseCounts(ctx context.Context, productUUID string) (map[string]int64, error) {
+	args := mrr.Called(ctx, productUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
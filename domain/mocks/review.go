@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockReviewRepository) Store(ctx context.Context, r *domain.Review) error {
+	args := mrr.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (mrr *MockReviewRepository) Exists(ctx context.Context, login string, productUUID string) (bool, error) {
+	args := mrr.Called(ctx, login, productUUID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mrr *MockReviewRepository) ListForProduct(ctx context.Context, productUUID string, p domain.Pagination) (*domain.ReviewPage, error) {
+	args := mrr.Called(ctx, productUUID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.ReviewPage{Items: args.Get(0).([]domain.Review), Total: int64(args.Int(1))}, args.Error(2)
+}
+
+func (mrr *MockReviewRepository) RatingStats(ctx context.Context, productUUID string) (int64, int64, error) {
+	args := mrr.Called(ctx, productUUID)
+	return int64(args.Int(0)), int64(args.Int(1)), args.Error(2)
+}
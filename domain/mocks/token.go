@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/stretchr/testify/mock"
@@ -20,3 +21,52 @@ func (mts *MockTokenService) IsValid(ctx context.Context, token domain.Token) (d
 	args := mts.Called(ctx, token)
 	return domain.IsValid(args.Bool(0)), args.Error(1)
 }
+
+func (mts *MockTokenService) GetInfo(ctx context.Context, token domain.Token) (*domain.TokenInfo, error) {
+	args := mts.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.TokenInfo{ID: args.String(0), Info: args.String(1), Role: args.String(2), Audience: args.String(3), ExpiresAt: args.Get(4).(time.Time)}, args.Error(5)
+}
+
+type MockRevokedTokenRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRevokedTokenRepository) Store(ctx context.Context, rt *domain.RevokedToken) error {
+	args := mrr.Called(ctx, rt)
+	return args.Error(0)
+}
+
+func (mrr *MockRevokedTokenRepository) Exists(ctx context.Context, id string) (bool, error) {
+	args := mrr.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRefreshTokenRepository) Store(ctx context.Context, rt *domain.RefreshToken) error {
+	args := mrr.Called(ctx, rt)
+	return args.Error(0)
+}
+
+func (mrr *MockRefreshTokenRepository) GetByValue(ctx context.Context, value string) (*domain.RefreshToken, error) {
+	args := mrr.Called(ctx, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.RefreshToken{Value: args.String(0), Login: args.String(1), Audience: args.String(2), ExpiresAt: args.Get(3).(time.Time)}, args.Error(4)
+}
+
+func (mrr *MockRefreshTokenRepository) DeleteByValue(ctx context.Context, value string) error {
+	args := mrr.Called(ctx, value)
+	return args.Error(0)
+}
+
+func (mrr *MockRefreshTokenRepository) DeleteByLogin(ctx context.Context, login string) error {
+	args := mrr.Called(ctx, login)
+	return args.Error(0)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (mtr *MockTwoFactorRepository) GetByLogin(ctx context.Context, login string) (*domain.TwoFactor, error) {
+	args := mtr.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.TwoFactor{Login: args.String(0), Secret: args.String(1), Enabled: args.Bool(2)}, args.Error(3)
+}
+
+func (mtr *MockTwoFactorRepository) Store(ctx context.Context, tf *domain.TwoFactor) error {
+	args := mtr.Called(ctx, tf)
+	return args.Error(0)
+}
+
+func (mtr *MockTwoFactorRepository) Enable(ctx context.Context, login string) error {
+	args := mtr.Called(ctx, login)
+	return args.Error(0)
+}
+
+func (mtr *MockTwoFactorRepository) StoreChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	args := mtr.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mtr *MockTwoFactorRepository) GetChallengeByValue(ctx context.Context, value string) (*domain.TwoFactorChallenge, error) {
+	args := mtr.Called(ctx, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.TwoFactorChallenge{Value: args.String(0), Login: args.String(1), Audience: args.String(2), ExpiresAt: args.Get(3).(time.Time)}, args.Error(4)
+}
+
+func (mtr *MockTwoFactorRepository) DeleteChallengeByValue(ctx context.Context, value string) error {
+	args := mtr.Called(ctx, value)
+	return args.Error(0)
+}
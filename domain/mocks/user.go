@@ -16,6 +16,36 @@ func (muv *MockUserValidator) Validate(ctx context.Context, u *domain.User) (dom
 	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
 }
 
+type MockUserUsecase struct {
+	mock.Mock
+}
+
+func (muu *MockUserUsecase) GetProfile(ctx context.Context, login string) (*domain.User, error) {
+	args := muu.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.User{ID: int64(args.Int(0)), UUID: args.String(1), Email: args.String(2), FirstName: args.String(3), LastName: args.String(4), PhoneNumber: args.String(5), Address: domain.UserAddress{City: args.String(6), State: args.String(7), Neighborhood: args.String(8), Street: args.String(9), Number: args.String(10), ZipCode: args.String(11)}}, args.Error(12)
+}
+
+func (muu *MockUserUsecase) RecordConsent(ctx context.Context, login string, purpose string, granted bool, source string) error {
+	args := muu.Called(ctx, login, purpose, granted, source)
+	return args.Error(0)
+}
+
+func (muu *MockUserUsecase) GetConsents(ctx context.Context, login string) ([]domain.Consent, error) {
+	args := muu.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Consent), args.Error(1)
+}
+
+func (muu *MockUserUsecase) UpdateProfile(ctx context.Context, login string, u *domain.User) error {
+	args := muu.Called(ctx, login, u)
+	return args.Error(0)
+}
+
 type MockUserRepository struct {
 	mock.Mock
 }
@@ -27,3 +57,16 @@ func (mur *MockUserRepository) GetByEmail(ctx context.Context, email string) (*d
 	}
 	return &domain.User{ID: int64(args.Int(0)), UUID: args.String(1), Email: args.String(2), FirstName: args.String(3), LastName: args.String(4), PhoneNumber: args.String(5), Address: domain.UserAddress{City: args.String(6), State: args.String(7), Neighborhood: args.String(8), Street: args.String(9), Number: args.String(10), ZipCode: args.String(11)}}, args.Error(12)
 }
+
+func (mur *MockUserRepository) GetByPhone(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	args := mur.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.User{ID: int64(args.Int(0)), UUID: args.String(1), Email: args.String(2), FirstName: args.String(3), LastName: args.String(4), PhoneNumber: args.String(5), Address: domain.UserAddress{City: args.String(6), State: args.String(7), Neighborhood: args.String(8), Street: args.String(9), Number: args.String(10), ZipCode: args.String(11)}}, args.Error(12)
+}
+
+func (mur *MockUserRepository) Update(ctx context.Context, u *domain.User) error {
+	args := mur.Called(ctx, u)
+	return args.Error(0)
+}
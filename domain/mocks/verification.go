@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailVerificationRepository struct {
+	mock.Mock
+}
+
+func (mvr *MockEmailVerificationRepository) Store(ctx context.Context, ev *domain.EmailVerification) error {
+	args := mvr.Called(ctx, ev)
+	return args.Error(0)
+}
+
+func (mvr *MockEmailVerificationRepository) GetByLogin(ctx context.Context, login string) (*domain.EmailVerification, error) {
+	args := mvr.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.EmailVerification{Login: args.String(0), Code: args.String(1), ExpiresAt: args.Get(2).(time.Time), Attempts: args.Int(3)}, args.Error(4)
+}
+
+func (mvr *MockEmailVerificationRepository) IncrementAttempts(ctx context.Context, login string) error {
+	args := mvr.Called(ctx, login)
+	return args.Error(0)
+}
+
+func (mvr *MockEmailVerificationRepository) DeleteByLogin(ctx context.Context, login string) error {
+	args := mvr.Called(ctx, login)
+	return args.Error(0)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (mwr *MockWishlistRepository) Add(ctx context.Context, login string, productUUID string) error {
+	args := mwr.Called(ctx, login, productUUID)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) Remove(ctx context.Context, login string, productUUID string) error {
+	args := mwr.Called(ctx, login, productUUID)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) List(ctx context.Context, login string) ([]string, error) {
+	args := mwr.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+)
+
+const (
+	CarrierStatusInTransit      = "in_transit"
+	CarrierStatusOutForDelivery = "out_for_delivery"
+	CarrierStatusDelivered      = "delivered"
+)
+
+type OrderItem struct {
+	ProductUUID string `json:"productUuid"`
+	Quantity    int64  `json:"quantity"`
+	UnitPrice   int64  `json:"unitPrice"`
+}
+
+type Order struct {
+	ID             int64
+	UUID           string      `json:"uuid"`
+	UserLogin      string      `json:"-"`
+	Items          []OrderItem `json:"items"`
+	Discount       int64       `json:"discount"`
+	CouponCode     string      `json:"couponCode"`
+	Total          int64       `json:"total"`
+	Status         string      `json:"status"`
+	Carrier        string      `json:"carrier"`
+	TrackingNumber string      `json:"trackingNumber"`
+	TrackingStatus string      `json:"trackingStatus"`
+	ShippedBy      string      `json:"-"`
+	CreatedAt      time.Time   `json:"createdAt"`
+}
+
+type OrderUseCase interface {
+	PlaceOrder(ctx context.Context, login string, couponCode string) (*Order, error)
+	ConfirmPayment(ctx context.Context, orderID string) error
+	AddTracking(ctx context.Context, staffLogin string, orderID string, carrier string, trackingNumber string) error
+	SyncTracking(ctx context.Context, orderID string) error
+}
+
+type OrderRepository interface {
+	StoreFromCart(ctx context.Context, o *Order) error
+	HasPurchased(ctx context.Context, login string, productUUID string) (bool, error)
+	GetByUUID(ctx context.Context, uuid string) (*Order, error)
+	MarkPaid(ctx context.Context, uuid string) error
+	AttachTracking(ctx context.Context, uuid string, carrier string, trackingNumber string, shippedBy string) error
+	UpdateTracking(ctx context.Context, uuid string, trackingStatus string, status string) error
+}
+
+type CarrierService interface {
+	TrackingStatus(ctx context.Context, trackingNumber string) (string, error)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "fmt"
+
+const (
+	DefaultPageLimit int64 = 20
+	MaxPageLimit     int64 = 100
+)
+
+type Pagination struct {
+	Limit  int64
+	Offset int64
+}
+
+// Normalize defaults the limit to 20 and caps it at 100.
+func (p Pagination) Normalize() (Pagination, error) {
+	if p.Offset < 0 {
+		return p, fmt.Errorf("pagination offset can not be negative, got %d", p.Offset)
+	}
+
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+
+	return p, nil
+}
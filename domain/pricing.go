@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// Money is an amount in cents.
+type Money int64
+
+// PricingConfig holds the tax rate of each region in basis points, 1800 is 18%.
+type PricingConfig struct {
+	DefaultRegion string
+	TaxRates      map[string]int64
+}
+
+type PricingService interface {
+	EstimateTax(ctx context.Context, subtotal Money, region string) (Money, error)
+}
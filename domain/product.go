@@ -8,20 +8,54 @@ type Attribute struct {
 }
 
 type Product struct {
-	ID         int64
-	UUID       string      `json:"uuid"`
-	Rate       float32     `json:"rate"`
-	Pictures   []string    `json:"pictures"`
-	Name       string      `json:"name"`
-	Detail     string      `json:"detail"`
-	Favorite   bool        `json:"favorite"`
-	Attributes []Attribute `json:"attributes"`
+	ID            int64
+	UUID          string      `json:"uuid"`
+	Rate          float32     `json:"rate"`
+	Pictures      []string    `json:"pictures"`
+	Name          string      `json:"name"`
+	Detail        string      `json:"detail"`
+	Favorite      bool        `json:"favorite"`
+	Attributes    []Attribute `json:"attributes"`
+	Price         int64       `json:"price"`
+	SKU           string      `json:"sku"`
+	StockQuantity int64       `json:"stockQuantity"`
+	CategoryID    string      `json:"categoryId"`
+	Active        bool        `json:"-"`
+}
+
+type ProductTranslation struct {
+	Locale string
+	Name   string
+	Detail string
+}
+
+type ProductConfig struct {
+	DefaultLocale string
+	MaxBulkItems  int
+}
+
+type ProductPage struct {
+	Items []Product `json:"items"`
+	Total int64     `json:"total"`
 }
 
 type ProductUseCase interface {
 	Get(ctx context.Context, uuid string) (*Product, error)
+	Create(ctx context.Context, p *Product) error
+	Import(ctx context.Context, products []Product) error
+	List(ctx context.Context, p Pagination) (*ProductPage, error)
+	Search(ctx context.Context, query string, categoryID *string, p Pagination) (*ProductPage, error)
+	Update(ctx context.Context, p *Product) error
+	Deactivate(ctx context.Context, uuid string) error
 }
 
 type ProductRepository interface {
 	GetByUUID(ctx context.Context, uuid string) (*Product, error)
+	Store(ctx context.Context, p *Product) error
+	StoreBatch(ctx context.Context, products []Product) error
+	List(ctx context.Context, p Pagination) (*ProductPage, error)
+	Search(ctx context.Context, query string, categoryID *string, p Pagination) (*ProductPage, error)
+	Update(ctx context.Context, p *Product) error
+	Deactivate(ctx context.Context, uuid string) error
+	GetTranslation(ctx context.Context, uuid string, locale string) (*ProductTranslation, error)
 }
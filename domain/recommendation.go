@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+type RecommendationUseCase interface {
+	RelatedProducts(ctx context.Context, productUUID string, limit int) ([]Product, error)
+}
+
+type RecommendationRepository interface {
+	CoPurchaseCounts(ctx context.Context, productUUID string) (map[string]int64, error)
+}
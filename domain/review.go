@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
+
+type Review struct {
+	ID          int64
+	ProductUUID string    `json:"productUuid"`
+	UserLogin   string    `json:"-"`
+	Rating      int       `json:"rating"`
+	Comment     string    `json:"comment"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ReviewPage struct {
+	Items []Review `json:"items"`
+	Total int64    `json:"total"`
+}
+
+type ReviewUseCase interface {
+	Create(ctx context.Context, r *Review) error
+	ListForProduct(ctx context.Context, productUUID string, p Pagination) (*ReviewPage, error)
+	AverageRating(ctx context.Context, productUUID string) (float64, error)
+}
+
+type ReviewRepository interface {
+	Store(ctx context.Context, r *Review) error
+	Exists(ctx context.Context, login string, productUUID string) (bool, error)
+	ListForProduct(ctx context.Context, productUUID string, p Pagination) (*ReviewPage, error)
+	RatingStats(ctx context.Context, productUUID string) (int64, int64, error)
+}
@@ -2,15 +2,91 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	TokenCookieName        = "token"
+	RefreshTokenCookieName = "refresh_token"
 )
 
 type Token string
 
+const (
+	TokenClaimInfo     = "info"
+	TokenClaimRole     = "role"
+	TokenClaimVerified = "verified"
+)
+
+type TokenPair struct {
+	Token        Token
+	RefreshToken Token
+}
+
+type RefreshToken struct {
+	Value     string
+	Login     string
+	Audience  string
+	ExpiresAt time.Time
+}
+
 type TokenInfo struct {
-	Info string
+	ID        string
+	Info      string
+	Role      string
+	Audience  string
+	Verified  bool
+	ExpiresAt time.Time
+}
+
+type tokenInfoKey struct{}
+
+// WithTokenInfo carries the caller of the request down to the use cases.
+func WithTokenInfo(ctx context.Context, info TokenInfo) context.Context {
+	return context.WithValue(ctx, tokenInfoKey{}, info)
+}
+
+// TokenInfoFromContext returns the zero TokenInfo, without a role, for an anonymous caller.
+func TokenInfoFromContext(ctx context.Context) TokenInfo {
+	info, _ := ctx.Value(tokenInfoKey{}).(TokenInfo)
+	return info
+}
+
+func RequireRole(info TokenInfo, role string) error {
+	if info.Role != role {
+		return fmt.Errorf("%w: login %s does not have the %s role", ErrForbidden, info.Info, role)
+	}
+
+	return nil
+}
+
+type RevokedToken struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+type TokenConfig struct {
+	ClaimsAllowlist []string
+	Encrypted       bool
+	EncryptionKey   []byte
+	Audiences       []string
 }
 
 type TokenService interface {
 	Sign(ctx context.Context, info TokenInfo, expirationInMinutes int64) (Token, error)
 	IsValid(ctx context.Context, token Token) (IsValid, error)
+	GetInfo(ctx context.Context, token Token) (*TokenInfo, error)
+}
+
+type RefreshTokenRepository interface {
+	Store(ctx context.Context, rt *RefreshToken) error
+	GetByValue(ctx context.Context, value string) (*RefreshToken, error)
+	DeleteByValue(ctx context.Context, value string) error
+	DeleteByLogin(ctx context.Context, login string) error
+}
+
+type RevokedTokenRepository interface {
+	Store(ctx context.Context, rt *RevokedToken) error
+	Exists(ctx context.Context, id string) (bool, error)
 }
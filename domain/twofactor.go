@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type TwoFactor struct {
+	Login   string
+	Secret  string
+	Enabled bool
+}
+
+type TwoFactorChallenge struct {
+	Value     string
+	Login     string
+	Audience  string
+	ExpiresAt time.Time
+}
+
+type TwoFactorRepository interface {
+	GetByLogin(ctx context.Context, login string) (*TwoFactor, error)
+	Store(ctx context.Context, tf *TwoFactor) error
+	Enable(ctx context.Context, login string) error
+	StoreChallenge(ctx context.Context, c *TwoFactorChallenge) error
+	GetChallengeByValue(ctx context.Context, value string) (*TwoFactorChallenge, error)
+	DeleteChallengeByValue(ctx context.Context, value string) error
+}
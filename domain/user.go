@@ -1,15 +1,20 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type User struct {
-	ID          int64
-	UUID        string      `json:"uuid"`
-	Email       string      `json:"email"`
-	FirstName   string      `json:"firstName"`
-	LastName    string      `json:"lastName"`
-	PhoneNumber string      `json:"phoneNumber"`
-	Address     UserAddress `json:"address"`
+	ID              int64
+	UUID            string      `json:"uuid"`
+	Email           string      `json:"email"`
+	FirstName       string      `json:"firstName"`
+	LastName        string      `json:"lastName"`
+	PhoneNumber     string      `json:"phoneNumber"`
+	Address         UserAddress `json:"address"`
+	TermsVersion    string      `json:"-"`
+	TermsAcceptedAt time.Time   `json:"-"`
 }
 
 type UserAddress struct {
@@ -21,8 +26,21 @@ type UserAddress struct {
 	ZipCode      string `json:"zipcode"`
 }
 
+type UserConfig struct {
+	UniquePhoneNumbers bool
+}
+
+type UserUseCase interface {
+	GetProfile(ctx context.Context, login string) (*User, error)
+	RecordConsent(ctx context.Context, login string, purpose string, granted bool, source string) error
+	GetConsents(ctx context.Context, login string) ([]Consent, error)
+	UpdateProfile(ctx context.Context, login string, u *User) error
+}
+
 type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByPhone(ctx context.Context, phoneNumber string) (*User, error)
+	Update(ctx context.Context, u *User) error
 }
 
 type UserValidator interface {
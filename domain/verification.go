@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type EmailVerification struct {
+	Login     string
+	Code      string
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+type EmailVerificationRepository interface {
+	Store(ctx context.Context, ev *EmailVerification) error
+	GetByLogin(ctx context.Context, login string) (*EmailVerification, error)
+	IncrementAttempts(ctx context.Context, login string) error
+	DeleteByLogin(ctx context.Context, login string) error
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type WishlistUseCase interface {
+	Add(ctx context.Context, login string, productUUID string) error
+	Remove(ctx context.Context, login string, productUUID string) error
+	List(ctx context.Context, login string) ([]Product, error)
+}
+
+type WishlistRepository interface {
+	Add(ctx context.Context, login string, productUUID string) error
+	Remove(ctx context.Context, login string, productUUID string) error
+	List(ctx context.Context, login string) ([]string, error)
+}
@@ -3,6 +3,8 @@ CREATE TABLE gocleanarch.auth (
 	uuid varchar(128) NOT NULL,
 	login varchar(150) NOT NULL,
 	password varchar(150) NOT NULL,
+	role varchar(50) DEFAULT 'customer' NOT NULL,
+	verified TINYINT(1) DEFAULT 0 NOT NULL,
 	CONSTRAINT auth_id_PK PRIMARY KEY (id),
   CONSTRAINT auth_id_UN UNIQUE KEY (id),
   CONSTRAINT auth_uuid_UN UNIQUE KEY (uuid),
@@ -25,6 +27,8 @@ CREATE TABLE gocleanarch.users (
 	address_street varchar(150) NOT NULL,
 	address_number varchar(20) NOT NULL,
 	address_zipcode varchar(100) NOT NULL,
+	terms_version varchar(50) DEFAULT '' NOT NULL,
+	terms_accepted_at DATETIME NULL,
 	CONSTRAINT user_id_PK PRIMARY KEY (id),
 	CONSTRAINT user_id_UN UNIQUE KEY (id),
 	CONSTRAINT user_uuid_UN UNIQUE KEY (uuid),
@@ -37,7 +41,115 @@ COLLATE=latin1_swedish_ci;
 
 CREATE TABLE gocleanarch.code (
 	value varchar(100) NOT NULL,
-	identifier varchar(100) NOT NULL
+	identifier varchar(100) NOT NULL,
+	channel varchar(20) DEFAULT '' NOT NULL,
+	expires_at DATETIME NULL,
+	created_at DATETIME NULL
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.code_consumed (
+	value varchar(100) NOT NULL,
+	identifier varchar(100) NOT NULL,
+	consumed_at DATETIME NOT NULL
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.credit_transaction (
+	id INT auto_increment NOT NULL,
+	user_uuid varchar(128) NOT NULL,
+	amount BIGINT NOT NULL,
+	reason varchar(50) NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT credit_transaction_id_PK PRIMARY KEY (id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.revoked_token (
+	token_id varchar(64) NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT revoked_token_id_PK PRIMARY KEY (token_id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.refresh_token (
+	value varchar(255) NOT NULL,
+	login varchar(150) NOT NULL,
+	audience varchar(100) DEFAULT '' NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT refresh_token_value_PK PRIMARY KEY (value)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.two_factor (
+	login varchar(150) NOT NULL,
+	secret varchar(64) NOT NULL,
+	enabled TINYINT(1) DEFAULT 0 NOT NULL,
+	CONSTRAINT two_factor_login_PK PRIMARY KEY (login)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.two_factor_challenge (
+	value varchar(255) NOT NULL,
+	login varchar(150) NOT NULL,
+	audience varchar(100) DEFAULT '' NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT two_factor_challenge_value_PK PRIMARY KEY (value)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.email_verification (
+	login varchar(150) NOT NULL,
+	code varchar(20) NOT NULL,
+	attempts INT DEFAULT 0 NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT email_verification_login_PK PRIMARY KEY (login)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.user_consent (
+	id INT auto_increment NOT NULL,
+	login varchar(150) NOT NULL,
+	purpose varchar(50) NOT NULL,
+	granted TINYINT(1) NOT NULL,
+	source varchar(50) NOT NULL,
+	recorded_at DATETIME NOT NULL,
+	CONSTRAINT user_consent_id_PK PRIMARY KEY (id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.address (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	login varchar(150) NOT NULL,
+	label varchar(50) NOT NULL,
+	street varchar(150) NOT NULL,
+	number varchar(20) NOT NULL,
+	city varchar(100) NOT NULL,
+	state varchar(100) NOT NULL,
+	zipcode varchar(100) NOT NULL,
+	country varchar(100) NOT NULL,
+	is_default TINYINT(1) DEFAULT 0 NOT NULL,
+	CONSTRAINT address_id_PK PRIMARY KEY (id),
+	CONSTRAINT address_uuid_UN UNIQUE KEY (uuid)
 )
 ENGINE=InnoDB
 DEFAULT CHARSET=latin1
@@ -48,10 +160,111 @@ CREATE TABLE gocleanarch.product (
 	uuid varchar(128) NOT NULL,
 	name varchar(150) NOT NULL,
 	detail varchar(250) NOT NULL,
+	price BIGINT DEFAULT 0 NOT NULL,
+	sku varchar(64) NOT NULL,
+	stock_quantity BIGINT DEFAULT 0 NOT NULL,
+	category_id varchar(128) DEFAULT '' NOT NULL,
+	active TINYINT(1) DEFAULT 1 NOT NULL,
 	CONSTRAINT `PRIMARY` PRIMARY KEY (id),
 	CONSTRAINT product_id_UN UNIQUE KEY (id),
 	CONSTRAINT product_uuid_UN UNIQUE KEY (uuid),
-	CONSTRAINT product_name_UN UNIQUE KEY (name)
+	CONSTRAINT product_name_UN UNIQUE KEY (name),
+	CONSTRAINT product_sku_UN UNIQUE KEY (sku)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_translation (
+	product_uuid varchar(128) NOT NULL,
+	locale varchar(20) NOT NULL,
+	name varchar(150) NOT NULL,
+	detail varchar(250) NOT NULL,
+	CONSTRAINT product_translation_PK PRIMARY KEY (product_uuid, locale)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.cart_item (
+	login varchar(150) NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	quantity BIGINT NOT NULL,
+	unit_price BIGINT NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT cart_item_PK PRIMARY KEY (login, product_uuid)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.wishlist_item (
+	login varchar(150) NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT wishlist_item_PK PRIMARY KEY (login, product_uuid)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.orders (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	login varchar(150) NOT NULL,
+	discount BIGINT DEFAULT 0 NOT NULL,
+	coupon_code varchar(50) DEFAULT '' NOT NULL,
+	total BIGINT NOT NULL,
+	status varchar(20) NOT NULL,
+	carrier varchar(50) DEFAULT '' NOT NULL,
+	tracking_number varchar(100) DEFAULT '' NOT NULL,
+	tracking_status varchar(30) DEFAULT '' NOT NULL,
+	shipped_by varchar(150) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT orders_id_PK PRIMARY KEY (id),
+	CONSTRAINT orders_uuid_UN UNIQUE KEY (uuid)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.order_item (
+	id INT auto_increment NOT NULL,
+	order_uuid varchar(128) NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	quantity BIGINT NOT NULL,
+	unit_price BIGINT NOT NULL,
+	CONSTRAINT order_item_id_PK PRIMARY KEY (id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.coupon (
+	id INT auto_increment NOT NULL,
+	code varchar(50) NOT NULL,
+	percent_off BIGINT DEFAULT 0 NOT NULL,
+	amount_off_cents BIGINT DEFAULT 0 NOT NULL,
+	expires_at DATETIME NULL,
+	max_uses BIGINT DEFAULT 0 NOT NULL,
+	used_count BIGINT DEFAULT 0 NOT NULL,
+	min_order_cents BIGINT DEFAULT 0 NOT NULL,
+	CONSTRAINT coupon_id_PK PRIMARY KEY (id),
+	CONSTRAINT coupon_code_UN UNIQUE KEY (code)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.review (
+	id INT auto_increment NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	login varchar(150) NOT NULL,
+	rating TINYINT NOT NULL,
+	comment varchar(1000) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT review_id_PK PRIMARY KEY (id),
+	CONSTRAINT review_login_product_UN UNIQUE KEY (login, product_uuid)
 )
 ENGINE=InnoDB
 DEFAULT CHARSET=latin1
@@ -4,9 +4,13 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 
+	_adminPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/admin/presentation"
+	_adminUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/admin/usecase"
 	_authPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/presentation"
 	_authRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/repository"
 	_authService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/service"
@@ -15,13 +19,18 @@ import (
 	_codeRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/code/repository"
 	_codeService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/code/service"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/config"
+	_creditRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/credit/repository"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	_messageService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/message/service"
 	_productPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/presentation"
 	_productRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/repository"
 	_productUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/usecase"
+	_tokenRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/repository"
 	_tokenService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/service"
+	_twoFactorRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/twofactor/repository"
 	_userRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/user/repository"
 	_userValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/user/validator"
+	_verificationRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/verification/repository"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -32,7 +41,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	dbConn, err := sql.Open(`mysql`, fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", conf.Database.User, conf.Database.Pass, conf.Database.Host, conf.Database.Port, conf.Database.Name))
+	dbConn, err := sql.Open(`mysql`, fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", conf.Database.User, conf.Database.Pass, conf.Database.Host, conf.Database.Port, conf.Database.Name))
 
 	if err != nil {
 		log.Fatal(err)
@@ -59,20 +68,80 @@ func main() {
 	codeRepo := _codeRepo.NewCodeMysqlRepository(dbConn)
 	userRepo := _userRepo.NewUserMysqlRepository(dbConn)
 	productRepo := _productRepo.NewProductMysqlRepository(dbConn)
+	creditRepo := _creditRepo.NewCreditMysqlRepository(dbConn)
+	revokedTokenRepo := _tokenRepo.NewRevokedTokenMysqlRepository(dbConn)
+	refreshTokenRepo := _tokenRepo.NewRefreshTokenMysqlRepository(dbConn)
+	emailVerificationRepo := _verificationRepo.NewEmailVerificationMysqlRepository(dbConn)
+	twoFactorRepo := _twoFactorRepo.NewTwoFactorMysqlRepository(dbConn)
+
+	authService, err := _authService.NewAuthService(conf.Auth.PasswordHashCost)
+
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	authService := _authService.NewAuthService()
 	codeService := _codeService.NewCodeService(codeRepo)
 	messageService := _messageService.NewMessageService()
-	tokenService := _tokenService.NewTokenService()
+	var tokenAudiences []string
+
+	for _, audience := range conf.Auth.ClientAudiences {
+		tokenAudiences = append(tokenAudiences, audience)
+	}
+
+	tokenService, err := _tokenService.NewTokenService(domain.TokenConfig{ClaimsAllowlist: conf.Token.Claims, Encrypted: conf.Token.Encrypted, EncryptionKey: []byte(conf.Token.EncryptionKey), Audiences: tokenAudiences})
+
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	authValidator := _authValidator.NewAuthValidator()
+	var breachChecker domain.BreachChecker
+
+	if conf.Auth.BreachCheck {
+		breachChecker = _authService.NewBreachService(&http.Client{Timeout: 3 * time.Second}, _authService.HIBPRangeURL)
+	}
+
+	authValidator := _authValidator.NewAuthValidator(domain.PasswordPolicy{
+		MinLength:       conf.Auth.Password.MinLength,
+		RequireUpper:    conf.Auth.Password.RequireUpper,
+		RequireLower:    conf.Auth.Password.RequireLower,
+		RequireDigit:    conf.Auth.Password.RequireDigit,
+		RequireSymbol:   conf.Auth.Password.RequireSymbol,
+		MinEntropyScore: conf.Auth.MinPasswordScore,
+	}, breachChecker)
 	userValidator := _userValidator.NewUserValidator()
 
-	authUsecase := _authUsecase.NewAuthUseCase(authService, tokenService, codeService, messageService, authRepo, userRepo)
-	productUsecase := _productUsecase.NewProductUseCase(productRepo)
+	authConf := domain.AuthConfig{
+		TokenTransport:              domain.TokenTransport(conf.Auth.TokenTransport),
+		DefaultRole:                 conf.Auth.DefaultRole,
+		FirstUserIsAdmin:            conf.Auth.FirstUserIsAdmin,
+		ResetRetryGraceSeconds:      conf.Auth.ResetRetryGrace,
+		InviteOnly:                  conf.Auth.InviteOnly,
+		ResetCodeSingleChannel:      conf.Auth.ResetCodeSingleChannel,
+		WelcomeCreditAmount:         conf.Auth.WelcomeCredit,
+		SignUpHoneypot:              conf.Auth.SignUpHoneypot,
+		LoginMinDurationMillis:      conf.Auth.LoginMinDuration,
+		ClientAudiences:             conf.Auth.ClientAudiences,
+		TokenTTLMinutes:             conf.Auth.TokenTTL,
+		RoleTTLMinutes:              conf.Token.RoleTTLs,
+		TermsVersion:                conf.Auth.TermsVersion,
+		RefreshTokenTTLMinutes:      conf.Auth.RefreshTokenTTL,
+		EmailVerificationTTLMinutes: conf.Auth.EmailVerificationTTL,
+		ResetCodeTTLMinutes:         conf.Auth.ResetCodeTTL,
+		ResetCodeCooldownSeconds:    conf.Auth.ResetCodeCooldown,
+		UniquePhoneNumbers:          conf.Auth.UniquePhoneNumbers,
+		TwoFactor:                   conf.Auth.TwoFactor,
+		TwoFactorIssuer:             conf.Auth.TwoFactorIssuer,
+	}
+
+	authUsecase := _authUsecase.NewAuthUseCase(authService, tokenService, codeService, messageService, authRepo, userRepo, creditRepo, revokedTokenRepo, refreshTokenRepo, emailVerificationRepo, twoFactorRepo, authValidator, authConf)
+	adminUsecase := _adminUsecase.NewAdminUseCase(codeRepo)
+	productUsecase := _productUsecase.NewProductUseCase(productRepo, domain.ProductConfig{DefaultLocale: conf.Product.DefaultLocale, MaxBulkItems: conf.Product.MaxBulkItems})
+
+	authMiddleware := _authPresentation.NewAuthMiddleware(authUsecase)
 
 	_authPresentation.NewAuthHandler(e, authUsecase, authValidator, userValidator)
-	_productPresentation.NewProductHandler(e, productUsecase, tokenService)
+	_productPresentation.NewProductHandler(e, productUsecase, authMiddleware)
+	_adminPresentation.NewAdminHandler(e, adminUsecase, authMiddleware)
 
 	log.Fatal(e.Start(conf.Server.Address))
 }
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+type orderMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewOrderMysqlRepository(conn *sql.DB) domain.OrderRepository {
+	return &orderMysqlRepository{Conn: conn}
+}
+
+func execTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	stmt, err := tx.PrepareContext(ctx, query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	exec, err := stmt.ExecContext(ctx, args...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return exec.RowsAffected()
+}
+
+// StoreFromCart reserves the stock of the order items, takes a use of its
+// coupon, stores the order and removes the ordered lines from the cart, all or
+// nothing. Stock and coupon uses are taken with guarded UPDATEs that only match
+// while enough is left, so concurrent checkouts can not oversell.
+func (r *orderMysqlRepository) StoreFromCart(ctx context.Context, o *domain.Order) error {
+	reserveStockQuery := `UPDATE product SET stock_quantity = stock_quantity - ? WHERE uuid = ? AND active = 1 AND stock_quantity >= ?;`
+	useCouponQuery := `UPDATE coupon SET used_count = used_count + 1 WHERE code = ? AND (max_uses = 0 OR used_count < max_uses);`
+	storeOrderQuery := `INSERT INTO orders (uuid, login, discount, coupon_code, total, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?);`
+	storeItemQuery := `INSERT INTO order_item (order_uuid, product_uuid, quantity, unit_price) VALUES (?, ?, ?, ?);`
+	clearCartItemQuery := `DELETE FROM cart_item WHERE login = ? AND product_uuid = ?;`
+
+	tx, err := r.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range o.Items {
+		affect, err := execTx(ctx, tx, reserveStockQuery, item.Quantity, item.ProductUUID, item.Quantity)
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if affect != 1 {
+			tx.Rollback()
+			return fmt.Errorf("%w: product %s can not reserve %d", domain.ErrInsufficientStock, item.ProductUUID, item.Quantity)
+		}
+	}
+
+	if o.CouponCode != "" {
+		affect, err := execTx(ctx, tx, useCouponQuery, o.CouponCode)
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if affect != 1 {
+			tx.Rollback()
+			return fmt.Errorf("%w: coupon %s has no uses left", domain.ErrInvalidCoupon, o.CouponCode)
+		}
+	}
+
+	o.UUID = uuid.NewString()
+
+	if _, err := execTx(ctx, tx, storeOrderQuery, o.UUID, o.UserLogin, o.Discount, o.CouponCode, o.Total, o.Status, o.CreatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, item := range o.Items {
+		if _, err := execTx(ctx, tx, storeItemQuery, o.UUID, item.ProductUUID, item.Quantity, item.UnitPrice); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// only the priced lines are removed, a line added to the cart meanwhile
+	// stays there for the next order
+	for _, item := range o.Items {
+		if _, err := execTx(ctx, tx, clearCartItemQuery, o.UserLogin, item.ProductUUID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *orderMysqlRepository) HasPurchased(ctx context.Context, login string, productUUID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM orders o JOIN order_item oi ON oi.order_uuid = o.uuid WHERE o.login = ? AND oi.product_uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, login, productUUID)
+
+	var total int64
+
+	if err := row.Scan(&total); err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+// GetByUUID returns the order without its items.
+func (r *orderMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Order, error) {
+	query := `SELECT id, uuid, login, discount, coupon_code, total, status, carrier, tracking_number, tracking_status, shipped_by, created_at FROM orders WHERE uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, uuid)
+
+	var res domain.Order
+
+	if err := row.Scan(&res.ID, &res.UUID, &res.UserLogin, &res.Discount, &res.CouponCode, &res.Total, &res.Status, &res.Carrier, &res.TrackingNumber, &res.TrackingStatus, &res.ShippedBy, &res.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// MarkPaid only moves a pending order, an order already paid or further along
+// is left as it is and an error returned.
+func (r *orderMysqlRepository) MarkPaid(ctx context.Context, uuid string) error {
+	query := `UPDATE orders SET status = ? WHERE uuid = ? AND status = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, domain.OrderStatusPaid, uuid, domain.OrderStatusPending)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("order %s is not pending", uuid)
+	}
+
+	return nil
+}
+
+// AttachTracking ships a paid order, the status guard keeps two staff members
+// from shipping the same order twice.
+func (r *orderMysqlRepository) AttachTracking(ctx context.Context, uuid string, carrier string, trackingNumber string, shippedBy string) error {
+	query := `UPDATE orders SET carrier = ?, tracking_number = ?, shipped_by = ?, status = ? WHERE uuid = ? AND status = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, carrier, trackingNumber, shippedBy, domain.OrderStatusShipped, uuid, domain.OrderStatusPaid)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("%w: order %s is not paid", domain.ErrOrderNotShippable, uuid)
+	}
+
+	return nil
+}
+
+func (r *orderMysqlRepository) UpdateTracking(ctx context.Context, uuid string, trackingStatus string, status string) error {
+	query := `UPDATE orders SET tracking_status = ?, status = ? WHERE uuid = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, trackingStatus, status, uuid); err != nil {
+		return err
+	}
+
+	return nil
+}
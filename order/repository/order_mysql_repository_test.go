@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	reserveStockQuery  = regexp.QuoteMeta("UPDATE product SET stock_quantity = stock_quantity - ? WHERE uuid = ? AND active = 1 AND stock_quantity >= ?;")
+	useCouponQuery     = regexp.QuoteMeta("UPDATE coupon SET used_count = used_count + 1 WHERE code = ? AND (max_uses = 0 OR used_count < max_uses);")
+	storeOrderQuery    = regexp.QuoteMeta("INSERT INTO orders (uuid, login, discount, coupon_code, total, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?);")
+	storeItemQuery     = regexp.QuoteMeta("INSERT INTO order_item (order_uuid, product_uuid, quantity, unit_price) VALUES (?, ?, ?, ?);")
+	clearCartItemQuery = regexp.QuoteMeta("DELETE FROM cart_item WHERE login = ? AND product_uuid = ?;")
+)
+
+func expectReserveStock(mock sqlmock.Sqlmock) {
+	mock.ExpectPrepare(reserveStockQuery)
+	mock.ExpectExec(reserveStockQuery).WithArgs(int64(2), "product 1", int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(reserveStockQuery)
+	mock.ExpectExec(reserveStockQuery).WithArgs(int64(1), "product 2", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func pendingOrder() *domain.Order {
+	return &domain.Order{
+		UserLogin: "login",
+		Items:     []domain.OrderItem{{ProductUUID: "product 1", Quantity: 2, UnitPrice: 1990}, {ProductUUID: "product 2", Quantity: 1, UnitPrice: 500}},
+		Total:     4480,
+		Status:    domain.OrderStatusPending,
+		CreatedAt: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestStoreFromCartStoreOrderError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	o := pendingOrder()
+
+	mock.ExpectBegin()
+	expectReserveStock(mock)
+	mock.ExpectPrepare(storeOrderQuery)
+	mock.ExpectExec(storeOrderQuery).WithArgs(sqlmock.AnyArg(), "login", int64(0), "", int64(4480), "pending", o.CreatedAt).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.StoreFromCart(context.Background(), o)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreFromCartInsufficientStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(reserveStockQuery)
+	mock.ExpectExec(reserveStockQuery).WithArgs(int64(2), "product 1", int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(reserveStockQuery)
+	mock.ExpectExec(reserveStockQuery).WithArgs(int64(1), "product 2", int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.StoreFromCart(context.Background(), pendingOrder())
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreFromCartCouponExhausted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	o := pendingOrder()
+	o.CouponCode = "OFF10"
+
+	mock.ExpectBegin()
+	expectReserveStock(mock)
+	mock.ExpectPrepare(useCouponQuery)
+	mock.ExpectExec(useCouponQuery).WithArgs("OFF10").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.StoreFromCart(context.Background(), o)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreFromCart(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	o := pendingOrder()
+	o.CouponCode = "OFF10"
+	o.Discount = 448
+	o.Total = 4032
+
+	mock.ExpectBegin()
+	expectReserveStock(mock)
+	mock.ExpectPrepare(useCouponQuery)
+	mock.ExpectExec(useCouponQuery).WithArgs("OFF10").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(storeOrderQuery)
+	mock.ExpectExec(storeOrderQuery).WithArgs(sqlmock.AnyArg(), "login", int64(448), "OFF10", int64(4032), "pending", o.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectPrepare(storeItemQuery)
+	mock.ExpectExec(storeItemQuery).WithArgs(sqlmock.AnyArg(), "product 1", int64(2), int64(1990)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectPrepare(storeItemQuery)
+	mock.ExpectExec(storeItemQuery).WithArgs(sqlmock.AnyArg(), "product 2", int64(1), int64(500)).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectPrepare(clearCartItemQuery)
+	mock.ExpectExec(clearCartItemQuery).WithArgs("login", "product 1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(clearCartItemQuery)
+	mock.ExpectExec(clearCartItemQuery).WithArgs("login", "product 2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.StoreFromCart(context.Background(), o)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, o.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHasPurchased(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders o JOIN order_item oi ON oi.order_uuid = o.uuid WHERE o.login = ? AND oi.product_uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("login", "product").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	purchased, err := orderMysqlRepository.HasPurchased(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+	assert.True(t, purchased)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByUUIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT id, uuid, login, discount, coupon_code, total, status, carrier, tracking_number, tracking_status, shipped_by, created_at FROM orders WHERE uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("order").WillReturnError(sql.ErrNoRows)
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	order, err := orderMysqlRepository.GetByUUID(context.Background(), "order")
+
+	assert.NoError(t, err)
+	assert.Nil(t, order)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMarkPaidNotPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE orders SET status = ? WHERE uuid = ? AND status = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(domain.OrderStatusPaid, "order", domain.OrderStatusPending).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.MarkPaid(context.Background(), "order")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMarkPaid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE orders SET status = ? WHERE uuid = ? AND status = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(domain.OrderStatusPaid, "order", domain.OrderStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.MarkPaid(context.Background(), "order")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAttachTrackingNotPaid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE orders SET carrier = ?, tracking_number = ?, shipped_by = ?, status = ? WHERE uuid = ? AND status = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("correios", "tracking", "staff login", domain.OrderStatusShipped, "order", domain.OrderStatusPaid).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.AttachTracking(context.Background(), "order", "correios", "tracking", "staff login")
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotShippable)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAttachTracking(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE orders SET carrier = ?, tracking_number = ?, shipped_by = ?, status = ? WHERE uuid = ? AND status = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("correios", "tracking", "staff login", domain.OrderStatusShipped, "order", domain.OrderStatusPaid).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.AttachTracking(context.Background(), "order", "correios", "tracking", "staff login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateTracking(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE orders SET tracking_status = ?, status = ? WHERE uuid = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(domain.CarrierStatusDelivered, domain.OrderStatusDelivered, "order").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	orderMysqlRepository := NewOrderMysqlRepository(db)
+
+	err = orderMysqlRepository.UpdateTracking(context.Background(), "order", domain.CarrierStatusDelivered, domain.OrderStatusDelivered)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
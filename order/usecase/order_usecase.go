@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type orderUseCase struct {
+	orderRepo      domain.OrderRepository
+	cartRepo       domain.CartRepository
+	productRepo    domain.ProductRepository
+	couponService  domain.CouponService
+	carrierService domain.CarrierService
+	messageService domain.MessageService
+	now            func() time.Time
+}
+
+func NewOrderUseCase(or domain.OrderRepository, cr domain.CartRepository, pr domain.ProductRepository, cs domain.CouponService, cas domain.CarrierService, ms domain.MessageService) domain.OrderUseCase {
+	return &orderUseCase{
+		orderRepo:      or,
+		cartRepo:       cr,
+		productRepo:    pr,
+		couponService:  cs,
+		carrierService: cas,
+		messageService: ms,
+		now:            time.Now,
+	}
+}
+
+// PlaceOrder prices the cart from the current products, the unit price kept in
+// the cart is never trusted. Stock and the optional coupon are checked here to
+// fail early, they are only taken when the order is stored so a failure leaves
+// nothing reserved.
+func (ou *orderUseCase) PlaceOrder(ctx context.Context, login string, couponCode string) (*domain.Order, error) {
+	items, err := ou.cartRepo.List(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, domain.ErrEmptyCart
+	}
+
+	order := &domain.Order{
+		UserLogin: login,
+		Status:    domain.OrderStatusPending,
+		CreatedAt: ou.now(),
+	}
+
+	for _, item := range items {
+		product, err := ou.productRepo.GetByUUID(ctx, item.ProductUUID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if product == nil || !product.Active {
+			return nil, fmt.Errorf("product %s is no longer available", item.ProductUUID)
+		}
+
+		if item.Quantity > product.StockQuantity {
+			return nil, fmt.Errorf("%w: product %s has %d in stock, asked for %d", domain.ErrInsufficientStock, item.ProductUUID, product.StockQuantity, item.Quantity)
+		}
+
+		order.Items = append(order.Items, domain.OrderItem{ProductUUID: item.ProductUUID, Quantity: item.Quantity, UnitPrice: product.Price})
+		order.Total += product.Price * item.Quantity
+	}
+
+	if couponCode != "" {
+		discount, err := ou.couponService.Discount(ctx, couponCode, order.Total)
+
+		if err != nil {
+			return nil, err
+		}
+
+		order.CouponCode = couponCode
+		order.Discount = discount
+		order.Total -= discount
+	}
+
+	if err := ou.orderRepo.StoreFromCart(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ConfirmPayment moves a pending order to paid, the state it can be shipped
+// from. Only admins confirm payments.
+func (ou *orderUseCase) ConfirmPayment(ctx context.Context, orderID string) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	return ou.orderRepo.MarkPaid(ctx, orderID)
+}
+
+// AddTracking ships a paid order with the carrier and tracking number staff
+// attach to it, and tells the customer. Only admins ship orders.
+func (ou *orderUseCase) AddTracking(ctx context.Context, staffLogin string, orderID string, carrier string, trackingNumber string) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if carrier == "" || trackingNumber == "" {
+		return fmt.Errorf("carrier and tracking number can not be empty")
+	}
+
+	order, err := ou.orderRepo.GetByUUID(ctx, orderID)
+
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return fmt.Errorf("order %s not found", orderID)
+	}
+
+	if order.Status != domain.OrderStatusPaid {
+		return fmt.Errorf("%w: order %s is %s", domain.ErrOrderNotShippable, orderID, order.Status)
+	}
+
+	if err := ou.orderRepo.AttachTracking(ctx, orderID, carrier, trackingNumber, staffLogin); err != nil {
+		return err
+	}
+
+	// the order is already shipped, failing to tell the customer must not
+	// make staff attach the tracking again
+	if err := ou.sendShippedMessage(ctx, order.UserLogin, orderID, carrier, trackingNumber); err != nil {
+		log.Printf("Error trying to notify shipment of order %s: %s", orderID, err.Error())
+	}
+
+	return nil
+}
+
+func (ou *orderUseCase) sendShippedMessage(ctx context.Context, login string, orderID string, carrier string, trackingNumber string) error {
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "email"
+	messageConf.To = login
+	messageConf.Subject = "Pedido enviado"
+	messageConf.Message = fmt.Sprintf("Seu pedido %s foi enviado pela %s, o código de rastreio é %s", orderID, carrier, trackingNumber)
+
+	return ou.messageService.SendMessage(ctx, &messageConf)
+}
+
+// SyncTracking reflects the carrier status on the order, only a delivered
+// shipment moves the order status forward.
+func (ou *orderUseCase) SyncTracking(ctx context.Context, orderID string) error {
+	order, err := ou.orderRepo.GetByUUID(ctx, orderID)
+
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return fmt.Errorf("order %s not found", orderID)
+	}
+
+	if order.TrackingNumber == "" {
+		return fmt.Errorf("order %s has not been shipped", orderID)
+	}
+
+	if order.Status == domain.OrderStatusDelivered {
+		return nil
+	}
+
+	trackingStatus, err := ou.carrierService.TrackingStatus(ctx, order.TrackingNumber)
+
+	if err != nil {
+		return err
+	}
+
+	status := order.Status
+
+	if trackingStatus == domain.CarrierStatusDelivered {
+		status = domain.OrderStatusDelivered
+	}
+
+	if trackingStatus == order.TrackingStatus && status == order.Status {
+		return nil
+	}
+
+	return ou.orderRepo.UpdateTracking(ctx, orderID, trackingStatus, status)
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPlaceOrderEmptyCart(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{}, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, nil, nil, nil)
+
+	_, err := orderUseCase.PlaceOrder(context.Background(), "login", "")
+
+	assert.ErrorIs(t, err, domain.ErrEmptyCart)
+	mockOrderRepo.AssertNotCalled(t, "StoreFromCart", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrderInsufficientStock(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 3, UnitPrice: 1990}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 2, true, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, nil, nil, nil)
+
+	_, err := orderUseCase.PlaceOrder(context.Background(), "login", "")
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	mockOrderRepo.AssertNotCalled(t, "StoreFromCart", mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrderStockTakenConcurrently(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 2, UnitPrice: 1990}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 2, true, nil)
+	mockOrderRepo.On("StoreFromCart", mock.Anything, mock.Anything).Return(domain.ErrInsufficientStock)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, nil, nil, nil)
+
+	order, err := orderUseCase.PlaceOrder(context.Background(), "login", "")
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	assert.Nil(t, order)
+}
+
+func TestPlaceOrderCartError(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return(nil, errors.New("error message"))
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, nil, nil, nil)
+
+	_, err := orderUseCase.PlaceOrder(context.Background(), "login", "")
+
+	assert.Error(t, err)
+}
+
+func TestPlaceOrder(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 2, UnitPrice: 1}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 5, true, nil)
+	mockOrderRepo.On("StoreFromCart", mock.Anything, &domain.Order{
+		UserLogin: "login",
+		Items:     []domain.OrderItem{{ProductUUID: "product", Quantity: 2, UnitPrice: 1990}},
+		Total:     3980,
+		Status:    domain.OrderStatusPending,
+		CreatedAt: now,
+	}).Return(nil)
+
+	uc := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, nil, nil, nil).(*orderUseCase)
+	uc.now = func() time.Time { return now }
+
+	order, err := uc.PlaceOrder(context.Background(), "login", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3980), order.Total)
+	assert.Equal(t, domain.OrderStatusPending, order.Status)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestPlaceOrderWithCoupon(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockCouponService := new(mocks.MockCouponService)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 2, UnitPrice: 1}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 5, true, nil)
+	mockCouponService.On("Discount", mock.Anything, "OFF10", int64(3980)).Return(398, nil)
+	mockOrderRepo.On("StoreFromCart", mock.Anything, mock.Anything).Return(nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, mockCouponService, nil, nil)
+
+	order, err := orderUseCase.PlaceOrder(context.Background(), "login", "OFF10")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(398), order.Discount)
+	assert.Equal(t, int64(3582), order.Total)
+	assert.Equal(t, "OFF10", order.CouponCode)
+}
+
+func TestPlaceOrderInvalidCoupon(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockCouponService := new(mocks.MockCouponService)
+
+	mockCartRepo.On("List", mock.Anything, "login").Return([]domain.CartItem{{ProductUUID: "product", Quantity: 2}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 5, true, nil)
+	mockCouponService.On("Discount", mock.Anything, "EXPIRED", int64(3980)).Return(0, domain.ErrInvalidCoupon)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, mockCartRepo, mockProductRepo, mockCouponService, nil, nil)
+
+	_, err := orderUseCase.PlaceOrder(context.Background(), "login", "EXPIRED")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCoupon)
+	mockProductRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "StoreFromCart", mock.Anything, mock.Anything)
+}
+
+var adminCtx = domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "staff login", Role: domain.RoleAdmin})
+
+func TestConfirmPaymentNotAdmin(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil)
+
+	err := orderUseCase.ConfirmPayment(context.Background(), "order")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockOrderRepo.AssertNotCalled(t, "MarkPaid", mock.Anything, mock.Anything)
+}
+
+func TestConfirmPayment(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("MarkPaid", mock.Anything, "order").Return(nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil)
+
+	err := orderUseCase.ConfirmPayment(adminCtx, "order")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestAddTrackingNotAdmin(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	ctx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer login", Role: domain.RoleCustomer})
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil)
+
+	err := orderUseCase.AddTracking(ctx, "customer login", "order", "correios", "tracking")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockOrderRepo.AssertNotCalled(t, "AttachTracking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddTrackingPendingOrder(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", UserLogin: "user@email.com", Status: domain.OrderStatusPending}, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, mockMessageService)
+
+	err := orderUseCase.AddTracking(adminCtx, "staff login", "order", "correios", "tracking")
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotShippable)
+	mockOrderRepo.AssertNotCalled(t, "AttachTracking", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestAddTracking(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", UserLogin: "user@email.com", Status: domain.OrderStatusPaid}, nil)
+	mockOrderRepo.On("AttachTracking", mock.Anything, "order", "correios", "tracking", "staff login").Return(nil)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(mc *domain.MessageConfig) bool {
+		return mc.To == "user@email.com" && strings.Contains(mc.Message, "correios") && strings.Contains(mc.Message, "tracking")
+	})).Return(nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, mockMessageService)
+
+	err := orderUseCase.AddTracking(adminCtx, "staff login", "order", "correios", "tracking")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestAddTrackingMessageErrorStillShips(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", UserLogin: "user@email.com", Status: domain.OrderStatusPaid}, nil)
+	mockOrderRepo.On("AttachTracking", mock.Anything, "order", "correios", "tracking", "staff login").Return(nil)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, mockMessageService)
+
+	err := orderUseCase.AddTracking(adminCtx, "staff login", "order", "correios", "tracking")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestSyncTrackingNotShipped(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusPending}, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.Error(t, err)
+	mockCarrierService.AssertNotCalled(t, "TrackingStatus", mock.Anything, mock.Anything)
+}
+
+func TestSyncTrackingCarrierError(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusPending, TrackingNumber: "tracking"}, nil)
+	mockCarrierService.On("TrackingStatus", mock.Anything, "tracking").Return("", errors.New("error message"))
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateTracking", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSyncTrackingInTransit(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusPending, TrackingNumber: "tracking"}, nil)
+	mockOrderRepo.On("UpdateTracking", mock.Anything, "order", domain.CarrierStatusInTransit, domain.OrderStatusPending).Return(nil)
+	mockCarrierService.On("TrackingStatus", mock.Anything, "tracking").Return(domain.CarrierStatusInTransit, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestSyncTrackingUnchanged(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusPending, TrackingNumber: "tracking", TrackingStatus: domain.CarrierStatusOutForDelivery}, nil)
+	mockCarrierService.On("TrackingStatus", mock.Anything, "tracking").Return(domain.CarrierStatusOutForDelivery, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateTracking", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSyncTrackingDelivered(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusPending, TrackingNumber: "tracking", TrackingStatus: domain.CarrierStatusOutForDelivery}, nil)
+	mockOrderRepo.On("UpdateTracking", mock.Anything, "order", domain.CarrierStatusDelivered, domain.OrderStatusDelivered).Return(nil)
+	mockCarrierService.On("TrackingStatus", mock.Anything, "tracking").Return(domain.CarrierStatusDelivered, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestSyncTrackingAlreadyDelivered(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCarrierService := new(mocks.MockCarrierService)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order").Return(&domain.Order{UUID: "order", Status: domain.OrderStatusDelivered, TrackingNumber: "tracking"}, nil)
+
+	orderUseCase := NewOrderUseCase(mockOrderRepo, nil, nil, nil, mockCarrierService, nil)
+
+	err := orderUseCase.SyncTracking(context.Background(), "order")
+
+	assert.NoError(t, err)
+	mockCarrierService.AssertNotCalled(t, "TrackingStatus", mock.Anything, mock.Anything)
+}
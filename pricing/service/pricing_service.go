@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type pricingService struct {
+	conf domain.PricingConfig
+}
+
+func NewPricingService(conf domain.PricingConfig) *pricingService {
+	return &pricingService{conf: conf}
+}
+
+// EstimateTax prices the tax of subtotal in region, rounded to the nearest
+// cent. Before the customer enters an address the region is empty and the
+// default region is used, a region without a configured rate falls back to
+// it as well since the result is only an estimate.
+func (ps *pricingService) EstimateTax(ctx context.Context, subtotal domain.Money, region string) (domain.Money, error) {
+	if subtotal < 0 {
+		return 0, fmt.Errorf("subtotal %d can not be negative", subtotal)
+	}
+
+	rate, ok := ps.conf.TaxRates[region]
+
+	if !ok {
+		rate, ok = ps.conf.TaxRates[ps.conf.DefaultRegion]
+	}
+
+	if !ok {
+		return 0, fmt.Errorf("no tax rate configured for region %s nor the default region %s", region, ps.conf.DefaultRegion)
+	}
+
+	return domain.Money((int64(subtotal)*rate + 5000) / 10000), nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var conf = domain.PricingConfig{DefaultRegion: "SP", TaxRates: map[string]int64{"SP": 1800, "RJ": 2000, "AM": 700}}
+
+func TestEstimateTaxDefaultRegion(t *testing.T) {
+	tax, err := NewPricingService(conf).EstimateTax(context.Background(), 10000, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money(1800), tax)
+}
+
+func TestEstimateTaxEnteredRegion(t *testing.T) {
+	ps := NewPricingService(conf)
+
+	estimated, err := ps.EstimateTax(context.Background(), 10000, "")
+
+	assert.NoError(t, err)
+
+	for region, expected := range map[string]domain.Money{"RJ": 2000, "AM": 700} {
+		tax, err := ps.EstimateTax(context.Background(), 10000, region)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tax)
+		assert.NotEqual(t, estimated, tax)
+	}
+}
+
+func TestEstimateTaxUnknownRegionUsesDefault(t *testing.T) {
+	tax, err := NewPricingService(conf).EstimateTax(context.Background(), 10000, "XX")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money(1800), tax)
+}
+
+func TestEstimateTaxRoundsToNearestCent(t *testing.T) {
+	ps := NewPricingService(conf)
+
+	tax, err := ps.EstimateTax(context.Background(), 1990, "SP")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money(358), tax)
+
+	tax, err = ps.EstimateTax(context.Background(), 1975, "SP")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money(356), tax)
+}
+
+func TestEstimateTaxWithoutDefaultRate(t *testing.T) {
+	_, err := NewPricingService(domain.PricingConfig{DefaultRegion: "SP", TaxRates: map[string]int64{"RJ": 2000}}).EstimateTax(context.Background(), 10000, "")
+
+	assert.Error(t, err)
+}
+
+func TestEstimateTaxNegativeSubtotal(t *testing.T) {
+	_, err := NewPricingService(conf).EstimateTax(context.Background(), -1, "SP")
+
+	assert.Error(t, err)
+}
@@ -1,8 +1,10 @@
 package presentation
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/labstack/echo/v4"
@@ -10,31 +12,15 @@ import (
 
 type productHandler struct {
 	ProductUseCase domain.ProductUseCase
-	TokenService   domain.TokenService
 }
 
-func NewProductHandler(e *echo.Echo, puc domain.ProductUseCase, ts domain.TokenService) *productHandler {
+func NewProductHandler(e *echo.Echo, puc domain.ProductUseCase, auth echo.MiddlewareFunc) *productHandler {
 	handler := &productHandler{
 		ProductUseCase: puc,
-		TokenService:   ts,
-	}
-
-	auth := func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			authHeader := c.Request().Header.Get("Authorization")
-			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, "request not authorized")
-			}
-			if isValid, err := handler.TokenService.IsValid(c.Request().Context(), domain.Token(authHeader)); err != nil {
-				return c.JSON(http.StatusInternalServerError, "failed to authorize request")
-			} else if !isValid {
-				return c.JSON(http.StatusUnauthorized, "request not authorized")
-			}
-			return next(c)
-		}
 	}
 
 	e.GET("/products/:uuid", handler.Get, auth)
+	e.DELETE("/products/:uuid", handler.Deactivate, auth)
 
 	return handler
 }
@@ -46,7 +32,13 @@ func (ph *productHandler) Get(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
 	}
 
-	product, err := ph.ProductUseCase.Get(c.Request().Context(), uuid)
+	ctx := c.Request().Context()
+
+	if locale := requestLocale(c.Request().Header.Get("Accept-Language")); locale != "" {
+		ctx = domain.WithLocale(ctx, locale)
+	}
+
+	product, err := ph.ProductUseCase.Get(ctx, uuid)
 
 	if err != nil {
 		log.Printf("Error trying to get a product: %s", err.Error())
@@ -59,3 +51,33 @@ func (ph *productHandler) Get(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, product)
 }
+
+func (ph *productHandler) Deactivate(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	if err := ph.ProductUseCase.Deactivate(c.Request().Context(), uuid); err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return c.JSON(http.StatusForbidden, "request not allowed")
+		}
+		log.Printf("Error trying to deactivate a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to deactivate the product")
+	}
+
+	return c.String(http.StatusOK, "")
+}
+
+// requestLocale takes the preferred tag of an Accept-Language header, "pt-BR,pt;q=0.9" gives "pt-BR".
+func requestLocale(acceptLanguage string) string {
+	tag := strings.SplitN(acceptLanguage, ",", 2)[0]
+	tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+
+	if tag == "*" {
+		return ""
+	}
+
+	return tag
+}
@@ -1,13 +1,17 @@
 package presentation
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	_authPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/presentation"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	_productUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/usecase"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,13 +46,10 @@ func TestGetErrorFind(t *testing.T) {
 	c.Request().Header.Set("Authorization", "token")
 
 	mockProductUsecase := new(mocks.MockProductUsecase)
-	mockTokenService := new(mocks.MockTokenService)
 
 	mockProductUsecase.On("Get", mock.Anything, "testuuid").Return(nil, errors.New("error message"))
 
-	mockTokenService.On("isValid", mock.Anything, "token").Return(true, nil)
-
-	handler := NewProductHandler(echo.New(), mockProductUsecase, mockTokenService)
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
 
 	handler.Get(c)
 
@@ -67,16 +68,102 @@ func TestGetSuccess(t *testing.T) {
 	c.SetParamValues("testuuid")
 
 	mockProductUsecase := new(mocks.MockProductUsecase)
-	mockTokenService := new(mocks.MockTokenService)
 
-	mockProductUsecase.On("Get", mock.Anything, "testuuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", nil)
+	mockProductUsecase.On("Get", mock.Anything, "testuuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", 1990, "sku", 10, true, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Get(c)
 
-	mockTokenService.On("isValid", mock.Anything, "token").Return(true, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"ID\":1,\"uuid\":\"uuid\",\"rate\":2,\"pictures\":[\"picturepath\"],\"name\":\"name\",\"detail\":\"detail\",\"favorite\":true,\"attributes\":[{\"label\":\"color\",\"values\":[\"black\"]}],\"price\":1990,\"sku\":\"sku\",\"stockQuantity\":10,\"categoryId\":\"\"}\n", rec.Body.String())
+}
 
-	handler := NewProductHandler(echo.New(), mockProductUsecase, mockTokenService)
+func TestGetAcceptLanguage(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	withLocale := mock.MatchedBy(func(ctx context.Context) bool { return domain.LocaleFromContext(ctx) == "pt-BR" })
+
+	mockProductUsecase.On("Get", withLocale, "testuuid").Return(1, "uuid", 2, "picturepath", "camiseta", "detalhe", true, "color", "black", 1990, "sku", 10, true, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
 
 	handler.Get(c)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Equal(t, "{\"ID\":1,\"uuid\":\"uuid\",\"rate\":2,\"pictures\":[\"picturepath\"],\"name\":\"name\",\"detail\":\"detail\",\"favorite\":true,\"attributes\":[{\"label\":\"color\",\"values\":[\"black\"]}]}\n", rec.Body.String())
+	mockProductUsecase.AssertExpectations(t)
+}
+
+// serveDeactivate sends DELETE /products/testuuid with token through the real
+// auth middleware and product use case, only the token decoding and the
+// repository are mocked.
+func serveDeactivate(token string, role string, mockProductRepo *mocks.MockProductRepository) *httptest.ResponseRecorder {
+	mockAuthUsecase := new(mocks.MockAuthUsecase)
+
+	mockAuthUsecase.On("VerifyToken", mock.Anything, domain.Token(token)).Return("valid login", role, nil)
+
+	e := echo.New()
+
+	NewProductHandler(e, _productUsecase.NewProductUseCase(mockProductRepo, domain.ProductConfig{}), _authPresentation.NewAuthMiddleware(mockAuthUsecase))
+
+	req := httptest.NewRequest(echo.DELETE, "/products/testuuid", nil)
+	req.Header.Set("Authorization", token)
+
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestDeactivateAsAdmin(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Deactivate", mock.Anything, "testuuid").Return(nil)
+
+	rec := serveDeactivate("admin token", domain.RoleAdmin, mockProductRepo)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestDeactivateAsCustomer(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	rec := serveDeactivate("customer token", domain.RoleCustomer, mockProductRepo)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockProductRepo.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything)
+}
+
+func TestDeactivateError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Deactivate", mock.Anything, "testuuid").Return(errors.New("error message"))
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Deactivate(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
 )
 
 type productMysqlRepository struct {
@@ -16,13 +19,197 @@ func NewProductMysqlRepository(conn *sql.DB) domain.ProductRepository {
 }
 
 func (pmr *productMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Product, error) {
-	query := `SELECT id, uuid, name, detail FROM product WHERE uuid = ?;`
+	query := `SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE uuid = ?;`
 
 	row := pmr.Conn.QueryRowContext(ctx, query, uuid)
 
 	var res domain.Product
 
-	if err := row.Scan(&res.ID, &res.UUID, &res.Name, &res.Detail); err != nil {
+	if err := row.Scan(&res.ID, &res.UUID, &res.Name, &res.Detail, &res.Price, &res.SKU, &res.StockQuantity, &res.CategoryID, &res.Active); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (pmr *productMysqlRepository) Store(ctx context.Context, p *domain.Product) error {
+	query := `INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	stmt, err := pmr.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	p.UUID = uuid.NewString()
+
+	exec, err := stmt.ExecContext(ctx, p.UUID, p.Name, p.Detail, p.Price, p.SKU, p.StockQuantity, p.CategoryID, p.Active)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store product with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// StoreBatch stores all the products or none of them.
+func (pmr *productMysqlRepository) StoreBatch(ctx context.Context, products []domain.Product) error {
+	query := `INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	tx, err := pmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, query)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range products {
+		p := &products[i]
+
+		p.UUID = uuid.NewString()
+
+		if _, err := stmt.ExecContext(ctx, p.UUID, p.Name, p.Detail, p.Price, p.SKU, p.StockQuantity, p.CategoryID, p.Active); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// List returns a page of the active products only, deactivated ones stay out of the catalog.
+func (pmr *productMysqlRepository) List(ctx context.Context, p domain.Pagination) (*domain.ProductPage, error) {
+	return pmr.page(ctx, "active = 1", nil, p)
+}
+
+// Search matches the query against name and detail ignoring case, the query is expected lower case already.
+func (pmr *productMysqlRepository) Search(ctx context.Context, query string, categoryID *string, p domain.Pagination) (*domain.ProductPage, error) {
+	where := "active = 1"
+	var args []interface{}
+
+	if query != "" {
+		pattern := "%" + likeEscaper.Replace(query) + "%"
+		where += " AND (LOWER(name) LIKE ? OR LOWER(detail) LIKE ?)"
+		args = append(args, pattern, pattern)
+	}
+
+	if categoryID != nil {
+		where += " AND category_id = ?"
+		args = append(args, *categoryID)
+	}
+
+	return pmr.page(ctx, where, args, p)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (pmr *productMysqlRepository) page(ctx context.Context, where string, args []interface{}, p domain.Pagination) (*domain.ProductPage, error) {
+	query := "SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE " + where + " ORDER BY id LIMIT ? OFFSET ?;"
+	countQuery := "SELECT COUNT(*) FROM product WHERE " + where + ";"
+
+	var res domain.ProductPage
+
+	if err := pmr.Conn.QueryRowContext(ctx, countQuery, args...).Scan(&res.Total); err != nil {
+		return nil, err
+	}
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, append(args, p.Limit, p.Offset)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var product domain.Product
+
+		if err := rows.Scan(&product.ID, &product.UUID, &product.Name, &product.Detail, &product.Price, &product.SKU, &product.StockQuantity, &product.CategoryID, &product.Active); err != nil {
+			return nil, err
+		}
+
+		res.Items = append(res.Items, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (pmr *productMysqlRepository) Update(ctx context.Context, p *domain.Product) error {
+	query := `UPDATE product SET name=?, detail=?, price=?, sku=?, stock_quantity=?, category_id=? WHERE uuid=?;`
+
+	stmt, err := pmr.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, p.Name, p.Detail, p.Price, p.SKU, p.StockQuantity, p.CategoryID, p.UUID)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to update product with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (pmr *productMysqlRepository) Deactivate(ctx context.Context, uuid string) error {
+	query := `UPDATE product SET active = 0 WHERE uuid = ?;`
+
+	stmt, err := pmr.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, uuid); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pmr *productMysqlRepository) GetTranslation(ctx context.Context, uuid string, locale string) (*domain.ProductTranslation, error) {
+	query := `SELECT locale, name, detail FROM product_translation WHERE product_uuid = ? AND locale = ?;`
+
+	row := pmr.Conn.QueryRowContext(ctx, query, uuid, locale)
+
+	var res domain.ProductTranslation
+
+	if err := row.Scan(&res.Locale, &res.Name, &res.Detail); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
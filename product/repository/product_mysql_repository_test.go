@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,9 +18,9 @@ func TestGetByUUIDNotFound(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail"})
+	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail", "price", "sku", "stock_quantity", "category_id", "active"})
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE uuid = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -42,7 +43,7 @@ func TestGetByUUIDError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE uuid = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -64,9 +65,9 @@ func TestGetByUUID(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail"}).AddRow(1, "uuid", "name", "detail")
+	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail", "price", "sku", "stock_quantity", "category_id", "active"}).AddRow(1, "uuid", "name", "detail", 1990, "sku", 10, "category", true)
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE uuid = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -79,6 +80,419 @@ func TestGetByUUID(t *testing.T) {
 	assert.Equal(t, "uuid", product.UUID)
 	assert.Equal(t, "name", product.Name)
 	assert.Equal(t, "detail", product.Detail)
+	assert.Equal(t, int64(1990), product.Price)
+	assert.Equal(t, "sku", product.SKU)
+	assert.Equal(t, int64(10), product.StockQuantity)
+	assert.Equal(t, "category", product.CategoryID)
+	assert.True(t, product.Active)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "name", "detail", int64(1990), "sku", int64(10), "category", true).WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	product := &domain.Product{Name: "name", Detail: "detail", Price: 1990, SKU: "sku", StockQuantity: 10, CategoryID: "category", Active: true}
+
+	err = productMysqlRepository.Store(context.Background(), product)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "name", "detail", int64(1990), "sku", int64(10), "category", true).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	product := &domain.Product{Name: "name", Detail: "detail", Price: 1990, SKU: "sku", StockQuantity: 10, CategoryID: "category", Active: true}
+
+	err = productMysqlRepository.Store(context.Background(), product)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, product.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreBatchRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "first", "", int64(1990), "sku 1", int64(0), "", true).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "second", "", int64(1990), "sku 2", int64(0), "", true).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	products := []domain.Product{{Name: "first", SKU: "sku 1", Price: 1990, Active: true}, {Name: "second", SKU: "sku 2", Price: 1990, Active: true}}
+
+	err = productMysqlRepository.StoreBatch(context.Background(), products)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO product (uuid, name, detail, price, sku, stock_quantity, category_id, active) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "first", "", int64(1990), "sku 1", int64(0), "", true).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "second", "", int64(1990), "sku 2", int64(0), "", true).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	products := []domain.Product{{Name: "first", SKU: "sku 1", Price: 1990, Active: true}, {Name: "second", SKU: "sku 2", Price: 1990, Active: true}}
+
+	err = productMysqlRepository.StoreBatch(context.Background(), products)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, products[0].UUID)
+	assert.NotEqual(t, products[0].UUID, products[1].UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListCountError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1;")
+
+	mock.ExpectQuery(countQuery).WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	_, err = productMysqlRepository.List(context.Background(), domain.Pagination{Limit: 20})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE active = 1 ORDER BY id LIMIT ? OFFSET ?;")
+
+	mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+	mock.ExpectQuery(query).WithArgs(int64(20), int64(0)).WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	_, err = productMysqlRepository.List(context.Background(), domain.Pagination{Limit: 20})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail", "price", "sku", "stock_quantity", "category_id", "active"}).
+		AddRow(3, "uuid 3", "name 3", "detail 3", 1990, "sku 3", 10, "category", true).
+		AddRow(4, "uuid 4", "name 4", "detail 4", 500, "sku 4", 0, "", true)
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE active = 1 ORDER BY id LIMIT ? OFFSET ?;")
+
+	mock.ExpectQuery(countQuery).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(5))
+	mock.ExpectQuery(query).WithArgs(int64(2), int64(2)).WillReturnRows(rows)
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	page, err := productMysqlRepository.List(context.Background(), domain.Pagination{Limit: 2, Offset: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, "uuid 3", page.Items[0].UUID)
+	assert.Equal(t, int64(1990), page.Items[0].Price)
+	assert.Equal(t, "uuid 4", page.Items[1].UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1 AND (LOWER(name) LIKE ? OR LOWER(detail) LIKE ?);")
+
+	mock.ExpectQuery(countQuery).WithArgs("%shirt%", "%shirt%").WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	_, err = productMysqlRepository.Search(context.Background(), "shirt", nil, domain.Pagination{Limit: 20})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail", "price", "sku", "stock_quantity", "category_id", "active"}).
+		AddRow(3, "uuid 3", "Red Shirt", "detail 3", 1990, "sku 3", 10, "category", true)
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1 AND (LOWER(name) LIKE ? OR LOWER(detail) LIKE ?) AND category_id = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE active = 1 AND (LOWER(name) LIKE ? OR LOWER(detail) LIKE ?) AND category_id = ? ORDER BY id LIMIT ? OFFSET ?;")
+
+	mock.ExpectQuery(countQuery).WithArgs("%100\\%%", "%100\\%%", "category").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(query).WithArgs("%100\\%%", "%100\\%%", "category", int64(20), int64(0)).WillReturnRows(rows)
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	category := "category"
+
+	page, err := productMysqlRepository.Search(context.Background(), "100%", &category, domain.Pagination{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "category", page.Items[0].CategoryID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchCategoryOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM product WHERE active = 1 AND category_id = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, name, detail, price, sku, stock_quantity, category_id, active FROM product WHERE active = 1 AND category_id = ? ORDER BY id LIMIT ? OFFSET ?;")
+
+	mock.ExpectQuery(countQuery).WithArgs("category").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+	mock.ExpectQuery(query).WithArgs("category", int64(20), int64(0)).WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "name", "detail", "price", "sku", "stock_quantity", "category_id", "active"}))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	category := "category"
+
+	page, err := productMysqlRepository.Search(context.Background(), "", &category, domain.Pagination{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), page.Total)
+	assert.Empty(t, page.Items)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE product SET name=?, detail=?, price=?, sku=?, stock_quantity=?, category_id=? WHERE uuid=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("name", "detail", int64(1990), "sku", int64(10), "category", "uuid").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	err = productMysqlRepository.Update(context.Background(), &domain.Product{UUID: "uuid", Name: "name", Detail: "detail", Price: 1990, SKU: "sku", StockQuantity: 10, CategoryID: "category"})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE product SET name=?, detail=?, price=?, sku=?, stock_quantity=?, category_id=? WHERE uuid=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("name", "detail", int64(1990), "sku", int64(10), "category", "uuid").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	err = productMysqlRepository.Update(context.Background(), &domain.Product{UUID: "uuid", Name: "name", Detail: "detail", Price: 1990, SKU: "sku", StockQuantity: 10, CategoryID: "category"})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeactivateError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE product SET active = 0 WHERE uuid = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid").WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	err = productMysqlRepository.Deactivate(context.Background(), "uuid")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeactivate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE product SET active = 0 WHERE uuid = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("uuid").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	err = productMysqlRepository.Deactivate(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetTranslationNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT locale, name, detail FROM product_translation WHERE product_uuid = ? AND locale = ?;")
+
+	mock.ExpectQuery(query).WithArgs("uuid", "pt-br").WillReturnRows(sqlmock.NewRows([]string{"locale", "name", "detail"}))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	translation, err := productMysqlRepository.GetTranslation(context.Background(), "uuid", "pt-br")
+
+	assert.NoError(t, err)
+	assert.Nil(t, translation)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT locale, name, detail FROM product_translation WHERE product_uuid = ? AND locale = ?;")
+
+	mock.ExpectQuery(query).WithArgs("uuid", "pt-br").WillReturnRows(sqlmock.NewRows([]string{"locale", "name", "detail"}).AddRow("pt-br", "camiseta", "detalhe"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	translation, err := productMysqlRepository.GetTranslation(context.Background(), "uuid", "pt-br")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ProductTranslation{Locale: "pt-br", Name: "camiseta", Detail: "detalhe"}, translation)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Error(err)
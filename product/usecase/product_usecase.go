@@ -2,18 +2,191 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
 
+const defaultLocale = "en"
+
+const defaultMaxBulkItems = 100
+
 type productUseCase struct {
 	productRepo domain.ProductRepository
+	conf        domain.ProductConfig
+}
+
+func NewProductUseCase(pr domain.ProductRepository, conf domain.ProductConfig) domain.ProductUseCase {
+	return &productUseCase{productRepo: pr, conf: conf}
+}
+
+func (pu *productUseCase) defaultLocale() string {
+	if pu.conf.DefaultLocale == "" {
+		return defaultLocale
+	}
+
+	return strings.ToLower(pu.conf.DefaultLocale)
 }
 
-func NewProductUseCase(pr domain.ProductRepository) domain.ProductUseCase {
-	return &productUseCase{productRepo: pr}
+func (pu *productUseCase) maxBulkItems() int {
+	if pu.conf.MaxBulkItems > 0 {
+		return pu.conf.MaxBulkItems
+	}
+
+	return defaultMaxBulkItems
 }
 
+// Get hides deactivated products as if they did not exist.
 func (pu *productUseCase) Get(ctx context.Context, uuid string) (*domain.Product, error) {
-	return pu.productRepo.GetByUUID(ctx, uuid)
+	product, err := pu.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil || product == nil || !product.Active {
+		return nil, err
+	}
+
+	if err := pu.localize(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// localize swaps name and detail for the translation of the context locale,
+// trying the language alone (pt for pt-br) before keeping the default content.
+func (pu *productUseCase) localize(ctx context.Context, p *domain.Product) error {
+	locale := strings.ToLower(domain.LocaleFromContext(ctx))
+
+	if locale == "" || locale == pu.defaultLocale() {
+		return nil
+	}
+
+	locales := []string{locale}
+
+	if i := strings.Index(locale, "-"); i > 0 && locale[:i] != pu.defaultLocale() {
+		locales = append(locales, locale[:i])
+	}
+
+	for _, l := range locales {
+		translation, err := pu.productRepo.GetTranslation(ctx, p.UUID, l)
+
+		if err != nil {
+			return err
+		}
+
+		if translation == nil {
+			continue
+		}
+
+		if translation.Name != "" {
+			p.Name = translation.Name
+		}
+
+		if translation.Detail != "" {
+			p.Detail = translation.Detail
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func validateProduct(p *domain.Product) error {
+	if p.Name == "" {
+		return fmt.Errorf("product name can not be empty")
+	}
+
+	if p.SKU == "" {
+		return fmt.Errorf("product sku can not be empty")
+	}
+
+	if p.Price < 0 {
+		return fmt.Errorf("product price can not be negative, got %d cents", p.Price)
+	}
+
+	if p.StockQuantity < 0 {
+		return fmt.Errorf("product stock quantity can not be negative, got %d", p.StockQuantity)
+	}
+
+	return nil
+}
+
+func (pu *productUseCase) Create(ctx context.Context, p *domain.Product) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+
+	p.Active = true
+
+	return pu.productRepo.Store(ctx, p)
+}
+
+// Import creates the products in order. The whole batch is checked, size
+// first, before any product is stored.
+func (pu *productUseCase) Import(ctx context.Context, products []domain.Product) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if len(products) > pu.maxBulkItems() {
+		return fmt.Errorf("%w: import takes at most %d products, got %d", domain.ErrTooManyItems, pu.maxBulkItems(), len(products))
+	}
+
+	for i := range products {
+		if err := validateProduct(&products[i]); err != nil {
+			return fmt.Errorf("product %d: %w", i, err)
+		}
+	}
+
+	for i := range products {
+		products[i].Active = true
+	}
+
+	return pu.productRepo.StoreBatch(ctx, products)
+}
+
+func (pu *productUseCase) List(ctx context.Context, p domain.Pagination) (*domain.ProductPage, error) {
+	p, err := p.Normalize()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.productRepo.List(ctx, p)
+}
+
+// Search lower cases the query so the match ignores case, an empty query lists by category only.
+func (pu *productUseCase) Search(ctx context.Context, query string, categoryID *string, p domain.Pagination) (*domain.ProductPage, error) {
+	p, err := p.Normalize()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.productRepo.Search(ctx, strings.ToLower(strings.TrimSpace(query)), categoryID, p)
+}
+
+func (pu *productUseCase) Update(ctx context.Context, p *domain.Product) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+
+	return pu.productRepo.Update(ctx, p)
+}
+
+func (pu *productUseCase) Deactivate(ctx context.Context, uuid string) error {
+	if err := domain.RequireRole(domain.TokenInfoFromContext(ctx), domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	return pu.productRepo.Deactivate(ctx, uuid)
 }
@@ -3,19 +3,23 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+var adminCtx = domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "admin", Role: domain.RoleAdmin})
+
 func TestGetError(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepository)
 
 	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, errors.New("error message"))
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
 
 	_, err := productUseCase.Get(context.Background(), "uuid")
 
@@ -27,7 +31,7 @@ func TestGetNotExists(t *testing.T) {
 
 	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
 
 	product, err := productUseCase.Get(context.Background(), "uuid")
 
@@ -38,9 +42,9 @@ func TestGetNotExists(t *testing.T) {
 func TestGet(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepository)
 
-	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", 1990, "sku", 10, true, nil)
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
 
 	product, err := productUseCase.Get(context.Background(), "uuid")
 
@@ -54,4 +58,431 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, true, product.Favorite)
 	assert.Equal(t, "color", product.Attributes[0].Label)
 	assert.Equal(t, "black", product.Attributes[0].Values[0])
+	assert.Equal(t, int64(1990), product.Price)
+	assert.Equal(t, "sku", product.SKU)
+	assert.Equal(t, int64(10), product.StockQuantity)
+}
+
+func TestGetInactive(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", 1990, "sku", 10, false, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	product, err := productUseCase.Get(context.Background(), "uuid")
+
+	assert.Nil(t, product)
+	assert.NoError(t, err)
+}
+
+func TestGetLocalized(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "shirt", "cotton shirt", false, "", "", 1990, "sku", 10, true, nil)
+	mockProductRepo.On("GetTranslation", mock.Anything, "uuid", "pt-br").Return("pt-br", "camiseta", "camiseta de algodao", nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	product, err := productUseCase.Get(domain.WithLocale(context.Background(), "pt-BR"), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "camiseta", product.Name)
+	assert.Equal(t, "camiseta de algodao", product.Detail)
+}
+
+func TestGetLocalizedFallsBackToLanguage(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "shirt", "cotton shirt", false, "", "", 1990, "sku", 10, true, nil)
+	mockProductRepo.On("GetTranslation", mock.Anything, "uuid", "pt-pt").Return(nil, nil)
+	mockProductRepo.On("GetTranslation", mock.Anything, "uuid", "pt").Return("pt", "camiseta", "", nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	product, err := productUseCase.Get(domain.WithLocale(context.Background(), "pt-PT"), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "camiseta", product.Name)
+	assert.Equal(t, "cotton shirt", product.Detail)
+}
+
+func TestGetLocaleMissingFallsBackToDefault(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "shirt", "cotton shirt", false, "", "", 1990, "sku", 10, true, nil)
+	mockProductRepo.On("GetTranslation", mock.Anything, "uuid", "fr").Return(nil, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	product, err := productUseCase.Get(domain.WithLocale(context.Background(), "fr"), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "shirt", product.Name)
+	assert.Equal(t, "cotton shirt", product.Detail)
+}
+
+func TestGetDefaultLocaleSkipsTranslation(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "camiseta", "camiseta de algodao", false, "", "", 1990, "sku", 10, true, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{DefaultLocale: "pt-BR"})
+
+	product, err := productUseCase.Get(domain.WithLocale(context.Background(), "pt-br"), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "camiseta", product.Name)
+	mockProductRepo.AssertNotCalled(t, "GetTranslation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTranslationError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "shirt", "cotton shirt", false, "", "", 1990, "sku", 10, true, nil)
+	mockProductRepo.On("GetTranslation", mock.Anything, "uuid", "fr").Return(nil, errors.New("error message"))
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.Get(domain.WithLocale(context.Background(), "fr"), "uuid")
+
+	assert.Error(t, err)
+}
+
+func TestCreateInvalid(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Create(adminCtx, &domain.Product{Name: "name", SKU: "sku", Price: -1})
+
+	assert.Error(t, err)
+
+	err = productUseCase.Create(adminCtx, &domain.Product{SKU: "sku", Price: 1990})
+
+	assert.Error(t, err)
+
+	err = productUseCase.Create(adminCtx, &domain.Product{Name: "name", Price: 1990})
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateForbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	customerCtx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer", Role: domain.RoleCustomer})
+
+	for _, ctx := range []context.Context{context.Background(), customerCtx} {
+		err := productUseCase.Create(ctx, &domain.Product{Name: "name", SKU: "sku", Price: 1990})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	}
+
+	mockProductRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateThenGet(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Store", mock.Anything, mock.MatchedBy(func(p *domain.Product) bool {
+		return p.Name == "name" && p.Price == 1990 && p.SKU == "sku" && p.StockQuantity == 10 && p.Active
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Product).UUID = "uuid"
+	}).Return(nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 0, "", "name", "detail", false, "", "", 1990, "sku", 10, true, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	created := &domain.Product{Name: "name", Detail: "detail", Price: 1990, SKU: "sku", StockQuantity: 10}
+
+	err := productUseCase.Create(adminCtx, created)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", created.UUID)
+
+	product, err := productUseCase.Get(context.Background(), created.UUID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created.Name, product.Name)
+	assert.Equal(t, created.Price, product.Price)
+	assert.Equal(t, created.SKU, product.SKU)
+	assert.Equal(t, created.StockQuantity, product.StockQuantity)
+}
+
+func importBatch(n int) []domain.Product {
+	products := make([]domain.Product, n)
+
+	for i := range products {
+		products[i] = domain.Product{Name: fmt.Sprintf("name %d", i), SKU: fmt.Sprintf("sku %d", i), Price: 1990}
+	}
+
+	return products
+}
+
+func TestImportAtLimit(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("StoreBatch", mock.Anything, mock.MatchedBy(func(products []domain.Product) bool {
+		for _, p := range products {
+			if !p.Active {
+				return false
+			}
+		}
+		return len(products) == 3
+	})).Return(nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{MaxBulkItems: 3})
+
+	err := productUseCase.Import(adminCtx, importBatch(3))
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertNumberOfCalls(t, "StoreBatch", 1)
+	mockProductRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestImportStoreBatchError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("StoreBatch", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Import(adminCtx, importBatch(3))
+
+	assert.Error(t, err)
+}
+
+func TestImportOverLimit(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{MaxBulkItems: 3})
+
+	err := productUseCase.Import(adminCtx, importBatch(4))
+
+	assert.ErrorIs(t, err, domain.ErrTooManyItems)
+	mockProductRepo.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestImportDefaultLimit(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Import(adminCtx, importBatch(101))
+
+	assert.ErrorIs(t, err, domain.ErrTooManyItems)
+	mockProductRepo.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestImportInvalidProductStoresNone(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	products := importBatch(3)
+	products[2].SKU = ""
+
+	err := productUseCase.Import(adminCtx, products)
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestImportForbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Import(context.Background(), importBatch(1))
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockProductRepo.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestListError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, domain.Pagination{Limit: 20}).Return(nil, errors.New("error message"))
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.List(context.Background(), domain.Pagination{})
+
+	assert.Error(t, err)
+}
+
+func TestListNegativeOffset(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.List(context.Background(), domain.Pagination{Limit: 10, Offset: -1})
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestListDefaultLimit(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, domain.Pagination{Limit: 20, Offset: 40}).Return([]domain.Product{}, 0, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.List(context.Background(), domain.Pagination{Offset: 40})
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestListCapsLimit(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, domain.Pagination{Limit: 100}).Return([]domain.Product{}, 0, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.List(context.Background(), domain.Pagination{Limit: 500})
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestList(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, domain.Pagination{Limit: 1, Offset: 1}).Return([]domain.Product{{UUID: "uuid", Name: "name", Price: 1990, SKU: "sku", Active: true}}, 3, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	page, err := productUseCase.List(context.Background(), domain.Pagination{Limit: 1, Offset: 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, int64(3), page.Total)
+}
+
+func TestSearchNegativeOffset(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	_, err := productUseCase.Search(context.Background(), "shirt", nil, domain.Pagination{Offset: -1})
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchIgnoresCase(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Search", mock.Anything, "red shirt", (*string)(nil), domain.Pagination{Limit: 20}).Return([]domain.Product{{UUID: "uuid", Name: "Red Shirt"}}, 1, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	page, err := productUseCase.Search(context.Background(), "  Red SHIRT ", nil, domain.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestSearchCategoryFilter(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	category := "category"
+
+	mockProductRepo.On("Search", mock.Anything, "shirt", &category, domain.Pagination{Limit: 100}).Return([]domain.Product{{UUID: "uuid", CategoryID: "category"}}, 1, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	page, err := productUseCase.Search(context.Background(), "shirt", &category, domain.Pagination{Limit: 500})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "category", page.Items[0].CategoryID)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestSearchEmptyQueryListsCategory(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	category := "category"
+
+	mockProductRepo.On("Search", mock.Anything, "", &category, domain.Pagination{Limit: 20}).Return([]domain.Product{{UUID: "uuid 1"}, {UUID: "uuid 2"}}, 2, nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	page, err := productUseCase.Search(context.Background(), "   ", &category, domain.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestUpdateInvalid(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Update(adminCtx, &domain.Product{UUID: "uuid", Name: "name", SKU: "sku", StockQuantity: -1})
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateForbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	ctx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer", Role: domain.RoleCustomer})
+
+	err := productUseCase.Update(ctx, &domain.Product{UUID: "uuid", Name: "name", SKU: "sku", Price: 2490})
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdate(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	product := &domain.Product{UUID: "uuid", Name: "name", SKU: "sku", Price: 2490, StockQuantity: 3}
+
+	mockProductRepo.On("Update", mock.Anything, product).Return(nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Update(adminCtx, product)
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestDeactivateForbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	ctx := domain.WithTokenInfo(context.Background(), domain.TokenInfo{Info: "customer", Role: domain.RoleCustomer})
+
+	err := productUseCase.Deactivate(ctx, "uuid")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockProductRepo.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything)
+}
+
+func TestDeactivate(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Deactivate", mock.Anything, "uuid").Return(nil)
+
+	productUseCase := NewProductUseCase(mockProductRepo, domain.ProductConfig{})
+
+	err := productUseCase.Deactivate(adminCtx, "uuid")
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertExpectations(t)
 }
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type recommendationMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewRecommendationMysqlRepository(conn *sql.DB) domain.RecommendationRepository {
+	return &recommendationMysqlRepository{Conn: conn}
+}
+
+// CoPurchaseCounts returns, for every other product, in how many orders it was
+// bought together with the given one.
+func (r *recommendationMysqlRepository) CoPurchaseCounts(ctx context.Context, productUUID string) (map[string]int64, error) {
+	query := `SELECT other.product_uuid, COUNT(DISTINCT other.order_uuid) FROM order_item oi JOIN order_item other ON other.order_uuid = oi.order_uuid AND other.product_uuid <> oi.product_uuid WHERE oi.product_uuid = ? GROUP BY other.product_uuid;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := map[string]int64{}
+
+	for rows.Next() {
+		var uuid string
+		var count int64
+
+		if err := rows.Scan(&uuid, &count); err != nil {
+			return nil, err
+		}
+
+		res[uuid] = count
+	}
+
+	return res, rows.Err()
+}
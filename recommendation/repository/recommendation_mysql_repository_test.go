@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoPurchaseCountsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT other.product_uuid, COUNT(DISTINCT other.order_uuid) FROM order_item oi JOIN order_item other ON other.order_uuid = oi.order_uuid AND other.product_uuid <> oi.product_uuid WHERE oi.product_uuid = ? GROUP BY other.product_uuid;")
+
+	mock.ExpectQuery(query).WithArgs("product").WillReturnError(errors.New("error message"))
+
+	recommendationMysqlRepository := NewRecommendationMysqlRepository(db)
+
+	_, err = recommendationMysqlRepository.CoPurchaseCounts(context.Background(), "product")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCoPurchaseCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_uuid", "COUNT(DISTINCT other.order_uuid)"}).
+		AddRow("product 2", 3).
+		AddRow("product 3", 1)
+
+	query := regexp.QuoteMeta("SELECT other.product_uuid, COUNT(DISTINCT other.order_uuid) FROM order_item oi JOIN order_item other ON other.order_uuid = oi.order_uuid AND other.product_uuid <> oi.product_uuid WHERE oi.product_uuid = ? GROUP BY other.product_uuid;")
+
+	mock.ExpectQuery(query).WithArgs("product").WillReturnRows(rows)
+
+	recommendationMysqlRepository := NewRecommendationMysqlRepository(db)
+
+	counts, err := recommendationMysqlRepository.CoPurchaseCounts(context.Background(), "product")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"product 2": 3, "product 3": 1}, counts)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const (
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+)
+
+type recommendationUseCase struct {
+	recommendationRepo domain.RecommendationRepository
+	productRepo        domain.ProductRepository
+}
+
+func NewRecommendationUseCase(rr domain.RecommendationRepository, pr domain.ProductRepository) domain.RecommendationUseCase {
+	return &recommendationUseCase{
+		recommendationRepo: rr,
+		productRepo:        pr,
+	}
+}
+
+// RelatedProducts returns the products most often bought in the same orders as
+// the given one, products never bought together with it are not recommended.
+func (ru *recommendationUseCase) RelatedProducts(ctx context.Context, productUUID string, limit int) ([]domain.Product, error) {
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+
+	counts, err := ru.recommendationRepo.CoPurchaseCounts(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := make([]string, 0, len(counts))
+
+	for uuid, count := range counts {
+		if count > 0 && uuid != productUUID {
+			uuids = append(uuids, uuid)
+		}
+	}
+
+	sort.Slice(uuids, func(i, j int) bool {
+		if counts[uuids[i]] != counts[uuids[j]] {
+			return counts[uuids[i]] > counts[uuids[j]]
+		}
+
+		return uuids[i] < uuids[j]
+	})
+
+	var res []domain.Product
+
+	for _, uuid := range uuids {
+		if len(res) == limit {
+			break
+		}
+
+		product, err := ru.productRepo.GetByUUID(ctx, uuid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if product == nil || !product.Active {
+			continue
+		}
+
+		res = append(res, *product)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockProduct(pr *mocks.MockProductRepository, uuid string, active bool) {
+	pr.On("GetByUUID", mock.Anything, uuid).Return(1, uuid, 0, "", "name", "detail", false, "", "", 1990, "sku", 10, active, nil)
+}
+
+func productUUIDs(products []domain.Product) []string {
+	var res []string
+
+	for _, p := range products {
+		res = append(res, p.UUID)
+	}
+
+	return res
+}
+
+func TestRelatedProductsError(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockRecommendationRepo.On("CoPurchaseCounts", mock.Anything, "shirt").Return(nil, errors.New("error message"))
+
+	recommendationUseCase := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo)
+
+	_, err := recommendationUseCase.RelatedProducts(context.Background(), "shirt", 5)
+
+	assert.Error(t, err)
+}
+
+func TestRelatedProductsMostBoughtTogetherFirst(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockRecommendationRepo.On("CoPurchaseCounts", mock.Anything, "shirt").Return(map[string]int64{"socks": 1, "pants": 4, "belt": 2}, nil)
+	mockProduct(mockProductRepo, "pants", true)
+	mockProduct(mockProductRepo, "belt", true)
+	mockProduct(mockProductRepo, "socks", true)
+
+	recommendationUseCase := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo)
+
+	products, err := recommendationUseCase.RelatedProducts(context.Background(), "shirt", 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pants", "belt", "socks"}, productUUIDs(products))
+}
+
+func TestRelatedProductsSkipsUnrelatedAndInactive(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockRecommendationRepo.On("CoPurchaseCounts", mock.Anything, "shirt").Return(map[string]int64{"pants": 3, "hat": 2, "mug": 0}, nil)
+	mockProduct(mockProductRepo, "pants", true)
+	mockProduct(mockProductRepo, "hat", false)
+
+	recommendationUseCase := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo)
+
+	products, err := recommendationUseCase.RelatedProducts(context.Background(), "shirt", 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pants"}, productUUIDs(products))
+	mockProductRepo.AssertNotCalled(t, "GetByUUID", mock.Anything, "mug")
+}
+
+func TestRelatedProductsLimit(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockRecommendationRepo.On("CoPurchaseCounts", mock.Anything, "shirt").Return(map[string]int64{"socks": 1, "pants": 4, "belt": 2}, nil)
+	mockProduct(mockProductRepo, "pants", true)
+	mockProduct(mockProductRepo, "belt", true)
+
+	recommendationUseCase := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo)
+
+	products, err := recommendationUseCase.RelatedProducts(context.Background(), "shirt", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pants", "belt"}, productUUIDs(products))
+	mockProductRepo.AssertNotCalled(t, "GetByUUID", mock.Anything, "socks")
+}
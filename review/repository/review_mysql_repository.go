@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type reviewMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewReviewMysqlRepository(conn *sql.DB) domain.ReviewRepository {
+	return &reviewMysqlRepository{Conn: conn}
+}
+
+func (r *reviewMysqlRepository) Store(ctx context.Context, rv *domain.Review) error {
+	query := `INSERT INTO review (product_uuid, login, rating, comment, created_at) VALUES (?, ?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, rv.ProductUUID, rv.UserLogin, rv.Rating, rv.Comment, rv.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store review with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *reviewMysqlRepository) Exists(ctx context.Context, login string, productUUID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM review WHERE login = ? AND product_uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, login, productUUID)
+
+	var total int64
+
+	if err := row.Scan(&total); err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+// ListForProduct returns the newest reviews first.
+func (r *reviewMysqlRepository) ListForProduct(ctx context.Context, productUUID string, p domain.Pagination) (*domain.ReviewPage, error) {
+	query := `SELECT id, product_uuid, login, rating, comment, created_at FROM review WHERE product_uuid = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;`
+	countQuery := `SELECT COUNT(*) FROM review WHERE product_uuid = ?;`
+
+	var res domain.ReviewPage
+
+	if err := r.Conn.QueryRowContext(ctx, countQuery, productUUID).Scan(&res.Total); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Conn.QueryContext(ctx, query, productUUID, p.Limit, p.Offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var rv domain.Review
+
+		if err := rows.Scan(&rv.ID, &rv.ProductUUID, &rv.UserLogin, &rv.Rating, &rv.Comment, &rv.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		res.Items = append(res.Items, rv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *reviewMysqlRepository) RatingStats(ctx context.Context, productUUID string) (int64, int64, error) {
+	query := `SELECT COALESCE(SUM(rating), 0), COUNT(*) FROM review WHERE product_uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, productUUID)
+
+	var sum, count int64
+
+	if err := row.Scan(&sum, &count); err != nil {
+		return 0, 0, err
+	}
+
+	return sum, count, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	createdAt := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	query := regexp.QuoteMeta("INSERT INTO review (product_uuid, login, rating, comment, created_at) VALUES (?, ?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("product", "login", 5, "great", createdAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	reviewMysqlRepository := NewReviewMysqlRepository(db)
+
+	err = reviewMysqlRepository.Store(context.Background(), &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: 5, Comment: "great", CreatedAt: createdAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM review WHERE login = ? AND product_uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("login", "product").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	reviewMysqlRepository := NewReviewMysqlRepository(db)
+
+	exists, err := reviewMysqlRepository.Exists(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListForProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	createdAt := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM review WHERE product_uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, product_uuid, login, rating, comment, created_at FROM review WHERE product_uuid = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;")
+
+	mock.ExpectQuery(countQuery).WithArgs("product").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+	mock.ExpectQuery(query).WithArgs("product", int64(1), int64(0)).WillReturnRows(sqlmock.NewRows([]string{"id", "product_uuid", "login", "rating", "comment", "created_at"}).AddRow(3, "product", "login", 4, "good", createdAt))
+
+	reviewMysqlRepository := NewReviewMysqlRepository(db)
+
+	page, err := reviewMysqlRepository.ListForProduct(context.Background(), "product", domain.Pagination{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Equal(t, []domain.Review{{ID: 3, ProductUUID: "product", UserLogin: "login", Rating: 4, Comment: "good", CreatedAt: createdAt}}, page.Items)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRatingStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COALESCE(SUM(rating), 0), COUNT(*) FROM review WHERE product_uuid = ?;")
+
+	mock.ExpectQuery(query).WithArgs("product").WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(13, 3))
+
+	reviewMysqlRepository := NewReviewMysqlRepository(db)
+
+	sum, count, err := reviewMysqlRepository.RatingStats(context.Background(), "product")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), sum)
+	assert.Equal(t, int64(3), count)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type reviewUseCase struct {
+	reviewRepo domain.ReviewRepository
+	orderRepo  domain.OrderRepository
+	now        func() time.Time
+}
+
+func NewReviewUseCase(rr domain.ReviewRepository, or domain.OrderRepository) domain.ReviewUseCase {
+	return &reviewUseCase{
+		reviewRepo: rr,
+		orderRepo:  or,
+		now:        time.Now,
+	}
+}
+
+// Create only accepts one review per login and product, from logins that
+// ordered the product.
+func (ru *reviewUseCase) Create(ctx context.Context, r *domain.Review) error {
+	if r.Rating < domain.MinReviewRating || r.Rating > domain.MaxReviewRating {
+		return fmt.Errorf("review rating must be between %d and %d, got %d", domain.MinReviewRating, domain.MaxReviewRating, r.Rating)
+	}
+
+	purchased, err := ru.orderRepo.HasPurchased(ctx, r.UserLogin, r.ProductUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if !purchased {
+		return fmt.Errorf("login %s can not review product %s: %w", r.UserLogin, r.ProductUUID, domain.ErrNotPurchased)
+	}
+
+	reviewed, err := ru.reviewRepo.Exists(ctx, r.UserLogin, r.ProductUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if reviewed {
+		return fmt.Errorf("login %s can not review product %s again: %w", r.UserLogin, r.ProductUUID, domain.ErrAlreadyReviewed)
+	}
+
+	r.CreatedAt = ru.now()
+
+	return ru.reviewRepo.Store(ctx, r)
+}
+
+func (ru *reviewUseCase) ListForProduct(ctx context.Context, productUUID string, p domain.Pagination) (*domain.ReviewPage, error) {
+	p, err := p.Normalize()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ru.reviewRepo.ListForProduct(ctx, productUUID, p)
+}
+
+// AverageRating is 0 for products without reviews.
+func (ru *reviewUseCase) AverageRating(ctx context.Context, productUUID string) (float64, error) {
+	sum, count, err := ru.reviewRepo.RatingStats(ctx, productUUID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	return float64(sum) / float64(count), nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRatingOutOfRange(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, mockOrderRepo)
+
+	for _, rating := range []int{0, 6} {
+		err := reviewUseCase.Create(context.Background(), &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: rating})
+
+		assert.Error(t, err)
+	}
+
+	mockReviewRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateNotPurchased(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("HasPurchased", mock.Anything, "login", "product").Return(false, nil)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, mockOrderRepo)
+
+	err := reviewUseCase.Create(context.Background(), &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: 4})
+
+	assert.ErrorIs(t, err, domain.ErrNotPurchased)
+	mockReviewRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreateAlreadyReviewed(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("HasPurchased", mock.Anything, "login", "product").Return(true, nil)
+	mockReviewRepo.On("Exists", mock.Anything, "login", "product").Return(true, nil)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, mockOrderRepo)
+
+	err := reviewUseCase.Create(context.Background(), &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: 4})
+
+	assert.ErrorIs(t, err, domain.ErrAlreadyReviewed)
+	mockReviewRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestCreate(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mockOrderRepo.On("HasPurchased", mock.Anything, "login", "product").Return(true, nil)
+	mockReviewRepo.On("Exists", mock.Anything, "login", "product").Return(false, nil)
+	mockReviewRepo.On("Store", mock.Anything, &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: 5, Comment: "great", CreatedAt: now}).Return(nil)
+
+	uc := NewReviewUseCase(mockReviewRepo, mockOrderRepo).(*reviewUseCase)
+	uc.now = func() time.Time { return now }
+
+	err := uc.Create(context.Background(), &domain.Review{ProductUUID: "product", UserLogin: "login", Rating: 5, Comment: "great"})
+
+	assert.NoError(t, err)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestListForProductCapsLimit(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("ListForProduct", mock.Anything, "product", domain.Pagination{Limit: 100}).Return([]domain.Review{{ProductUUID: "product", Rating: 5}}, 1, nil)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, nil)
+
+	page, err := reviewUseCase.ListForProduct(context.Background(), "product", domain.Pagination{Limit: 1000})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+}
+
+func TestAverageRatingError(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("RatingStats", mock.Anything, "product").Return(0, 0, errors.New("error message"))
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, nil)
+
+	_, err := reviewUseCase.AverageRating(context.Background(), "product")
+
+	assert.Error(t, err)
+}
+
+func TestAverageRatingWithoutReviews(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("RatingStats", mock.Anything, "product").Return(0, 0, nil)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, nil)
+
+	average, err := reviewUseCase.AverageRating(context.Background(), "product")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), average)
+}
+
+func TestAverageRating(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("RatingStats", mock.Anything, "product").Return(13, 3, nil)
+
+	reviewUseCase := NewReviewUseCase(mockReviewRepo, nil)
+
+	average, err := reviewUseCase.AverageRating(context.Background(), "product")
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 4.333, average, 0.001)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type refreshTokenMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewRefreshTokenMysqlRepository(conn *sql.DB) domain.RefreshTokenRepository {
+	return &refreshTokenMysqlRepository{Conn: conn}
+}
+
+func (r *refreshTokenMysqlRepository) Store(ctx context.Context, rt *domain.RefreshToken) error {
+	query := `INSERT INTO refresh_token (value, login, audience, expires_at) VALUES (?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, rt.Value, rt.Login, rt.Audience, rt.ExpiresAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store refresh token with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *refreshTokenMysqlRepository) GetByValue(ctx context.Context, value string) (*domain.RefreshToken, error) {
+	query := `SELECT value, login, audience, expires_at FROM refresh_token WHERE value = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, value)
+
+	var res domain.RefreshToken
+
+	if err := row.Scan(&res.Value, &res.Login, &res.Audience, &res.ExpiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *refreshTokenMysqlRepository) DeleteByValue(ctx context.Context, value string) error {
+	query := `DELETE FROM refresh_token WHERE value = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, value)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to remove refresh token with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// DeleteByLogin removes every refresh token of a login, having none is not an error.
+func (r *refreshTokenMysqlRepository) DeleteByLogin(ctx context.Context, login string) error {
+	query := `DELETE FROM refresh_token WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, login)
+
+	return err
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreRefreshTokenError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO refresh_token (value, login, audience, expires_at) VALUES (?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("value", "login", "audience", expiresAt).WillReturnError(errors.New("error message"))
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	err = refreshTokenMysqlRepository.Store(context.Background(), &domain.RefreshToken{Value: "value", Login: "login", Audience: "audience", ExpiresAt: expiresAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO refresh_token (value, login, audience, expires_at) VALUES (?, ?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("value", "login", "audience", expiresAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	err = refreshTokenMysqlRepository.Store(context.Background(), &domain.RefreshToken{Value: "value", Login: "login", Audience: "audience", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetRefreshTokenByValueNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"value", "login", "audience", "expires_at"})
+
+	query := regexp.QuoteMeta("SELECT value, login, audience, expires_at FROM refresh_token WHERE value = ?;")
+
+	mock.ExpectQuery(query).WithArgs("value").WillReturnRows(rows)
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	refreshToken, err := refreshTokenMysqlRepository.GetByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+	assert.Nil(t, refreshToken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetRefreshTokenByValue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"value", "login", "audience", "expires_at"}).AddRow("value", "login", "audience", expiresAt)
+
+	query := regexp.QuoteMeta("SELECT value, login, audience, expires_at FROM refresh_token WHERE value = ?;")
+
+	mock.ExpectQuery(query).WithArgs("value").WillReturnRows(rows)
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	refreshToken, err := refreshTokenMysqlRepository.GetByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RefreshToken{Value: "value", Login: "login", Audience: "audience", ExpiresAt: expiresAt}, refreshToken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteRefreshTokenByValue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM refresh_token WHERE value = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("value").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	err = refreshTokenMysqlRepository.DeleteByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteRefreshTokensByLogin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM refresh_token WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	refreshTokenMysqlRepository := NewRefreshTokenMysqlRepository(db)
+
+	err = refreshTokenMysqlRepository.DeleteByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
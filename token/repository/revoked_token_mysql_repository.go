@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type revokedTokenMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewRevokedTokenMysqlRepository(conn *sql.DB) domain.RevokedTokenRepository {
+	return &revokedTokenMysqlRepository{Conn: conn}
+}
+
+func (r *revokedTokenMysqlRepository) Store(ctx context.Context, rt *domain.RevokedToken) error {
+	query := `INSERT INTO revoked_token (token_id, expires_at) VALUES (?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, rt.ID, rt.ExpiresAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store revoked token with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *revokedTokenMysqlRepository) Exists(ctx context.Context, id string) (bool, error) {
+	query := `SELECT COUNT(*) FROM revoked_token WHERE token_id = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, id)
+
+	var total int64
+
+	if err := row.Scan(&total); err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO revoked_token (token_id, expires_at) VALUES (?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("token id", expiresAt).WillReturnError(errors.New("error message"))
+
+	revokedTokenMysqlRepository := NewRevokedTokenMysqlRepository(db)
+
+	err = revokedTokenMysqlRepository.Store(context.Background(), &domain.RevokedToken{ID: "token id", ExpiresAt: expiresAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO revoked_token (token_id, expires_at) VALUES (?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("token id", expiresAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	revokedTokenMysqlRepository := NewRevokedTokenMysqlRepository(db)
+
+	err = revokedTokenMysqlRepository.Store(context.Background(), &domain.RevokedToken{ID: "token id", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExistsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM revoked_token WHERE token_id = ?;")
+
+	mock.ExpectQuery(query).WithArgs("token id").WillReturnError(errors.New("error message"))
+
+	revokedTokenMysqlRepository := NewRevokedTokenMysqlRepository(db)
+
+	_, err = revokedTokenMysqlRepository.Exists(context.Background(), "token id")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"total"}).AddRow(1)
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM revoked_token WHERE token_id = ?;")
+
+	mock.ExpectQuery(query).WithArgs("token id").WillReturnRows(rows)
+
+	revokedTokenMysqlRepository := NewRevokedTokenMysqlRepository(db)
+
+	revoked, err := revokedTokenMysqlRepository.Exists(context.Background(), "token id")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jweHeader is the protected header of compact JWE tokens encrypted
+// directly with the shared key using AES-256-GCM.
+var jweHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"dir","enc":"A256GCM","typ":"JWT"}`))
+
+func encryptClaims(key []byte, claims *Claims) (string, error) {
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nil, iv, payload, []byte(jweHeader))
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return strings.Join([]string{
+		jweHeader,
+		"",
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, "."), nil
+}
+
+func decryptClaims(key []byte, token string, claims *Claims) error {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 5 || parts[0] != jweHeader || parts[1] != "" {
+		return fmt.Errorf("token is not a supported encrypted token")
+	}
+
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return err
+	}
+
+	var decoded [3][]byte
+
+	for i, part := range parts[2:] {
+		if decoded[i], err = base64.RawURLEncoding.DecodeString(part); err != nil {
+			return err
+		}
+	}
+
+	iv, ciphertext, tag := decoded[0], decoded[1], decoded[2]
+
+	if len(iv) != gcm.NonceSize() {
+		return fmt.Errorf("token has an invalid initialization vector")
+	}
+
+	payload, err := gcm.Open(nil, iv, append(ciphertext, tag...), []byte(parts[0]))
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, claims)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must have 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
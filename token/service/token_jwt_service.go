@@ -2,55 +2,159 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 var jwtKey = []byte("my_secret_key")
 
 type Claims struct {
-	Info string
+	Info     string `json:"Info,omitempty"`
+	Role     string `json:"Role,omitempty"`
+	Verified bool   `json:"Verified,omitempty"`
 	jwt.StandardClaims
 }
 
-type tokenService struct{}
+type tokenService struct {
+	conf domain.TokenConfig
+}
+
+// NewTokenService refuses an encryption key AES-256-GCM can not use, so a bad
+// token.encryptionKey stops the startup instead of failing every login.
+func NewTokenService(conf domain.TokenConfig) (*tokenService, error) {
+	if conf.Encrypted {
+		if _, err := newGCM(conf.EncryptionKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tokenService{conf: conf}, nil
+}
+
+func (t *tokenService) claimAllowed(claim string) bool {
+	if len(t.conf.ClaimsAllowlist) == 0 {
+		return true
+	}
+
+	for _, allowed := range t.conf.ClaimsAllowlist {
+		if allowed == claim {
+			return true
+		}
+	}
+
+	return false
+}
+
+// audienceAllowed tells whether the audience of a token is accepted, any
+// audience is when none are configured.
+func (t *tokenService) audienceAllowed(claims *Claims) bool {
+	if len(t.conf.Audiences) == 0 {
+		return true
+	}
+
+	for _, audience := range t.conf.Audiences {
+		if claims.VerifyAudience(audience, true) {
+			return true
+		}
+	}
 
-func NewTokenService() *tokenService {
-	return &tokenService{}
+	return false
 }
 
 func (t *tokenService) Sign(ctx context.Context, info domain.TokenInfo, expirationInMinutes int64) (domain.Token, error) {
 	expirationTime := time.Now().Add(time.Duration(expirationInMinutes) * time.Minute)
 
 	claims := &Claims{
-		Info: info.Info,
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
 			ExpiresAt: expirationTime.Unix(),
+			Audience:  info.Audience,
 		},
 	}
 
+	if t.claimAllowed(domain.TokenClaimInfo) {
+		claims.Info = info.Info
+	}
+
+	if t.claimAllowed(domain.TokenClaimRole) {
+		claims.Role = info.Role
+	}
+
+	if t.claimAllowed(domain.TokenClaimVerified) {
+		claims.Verified = info.Verified
+	}
+
+	if t.conf.Encrypted {
+		tokenString, err := encryptClaims(t.conf.EncryptionKey, claims)
+		return domain.Token(tokenString), err
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(jwtKey)
 
 	return domain.Token(tokenString), err
 }
 
-func (t *tokenService) IsValid(ctx context.Context, token domain.Token) (domain.IsValid, error) {
+// parse returns the claims of a valid token, decrypting it first when tokens are encrypted.
+func (t *tokenService) parse(token domain.Token) (*Claims, error) {
 	claims := &Claims{}
 
-	tkn, err := jwt.ParseWithClaims(string(token), claims, func(t *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
-	})
+	if t.conf.Encrypted {
+		if err := decryptClaims(t.conf.EncryptionKey, string(token), claims); err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return false, err
+		if err := claims.Valid(); err != nil {
+			return nil, err
+		}
+	} else {
+		tkn, err := jwt.ParseWithClaims(string(token), claims, func(t *jwt.Token) (interface{}, error) {
+			return jwtKey, nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !tkn.Valid {
+			return nil, fmt.Errorf("token is not valid")
+		}
+	}
+
+	if !t.audienceAllowed(claims) {
+		return nil, fmt.Errorf("token audience %s is not accepted", claims.Audience)
 	}
 
-	if !tkn.Valid {
-		return false, nil
+	return claims, nil
+}
+
+// IsValid only checks the signature and expiration, AuthUseCase.VerifyToken
+// also checks the revoked tokens.
+func (t *tokenService) IsValid(ctx context.Context, token domain.Token) (domain.IsValid, error) {
+	if _, err := t.parse(token); err != nil {
+		return false, err
 	}
 
 	return true, nil
 }
+
+func (t *tokenService) GetInfo(ctx context.Context, token domain.Token) (*domain.TokenInfo, error) {
+	claims, err := t.parse(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenInfo{
+		ID:        claims.Id,
+		Info:      claims.Info,
+		Role:      claims.Role,
+		Audience:  claims.Audience,
+		Verified:  claims.Verified,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
@@ -2,21 +2,33 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+func newTokenService(t *testing.T, conf domain.TokenConfig) *tokenService {
+	ts, err := NewTokenService(conf)
+
+	assert.NoError(t, err)
+
+	return ts
+}
+
 func TestSign(t *testing.T) {
-	token, err := NewTokenService().Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
+	token, err := newTokenService(t, domain.TokenConfig{}).Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
 
 func TestIsValidTokenInvalid(t *testing.T) {
-	ts := NewTokenService()
+	ts := newTokenService(t, domain.TokenConfig{})
 
 	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
 
@@ -27,12 +39,177 @@ func TestIsValidTokenInvalid(t *testing.T) {
 }
 
 func TestIsValid(t *testing.T) {
-	ts := NewTokenService()
+	ts := newTokenService(t, domain.TokenConfig{})
+
+	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
+
+	isValid, err := ts.IsValid(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.True(t, bool(isValid))
+}
+
+func TestGetInfoInvalidToken(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{})
 
 	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
 
+	info, err := ts.GetInfo(context.Background(), token+"invalid string")
+
+	assert.Error(t, err)
+	assert.Nil(t, info)
+}
+
+func TestGetInfo(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{})
+
+	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info", Role: domain.RoleAdmin, Audience: "e-commerce-web", Verified: true}, 10)
+
+	info, err := ts.GetInfo(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, info.ID)
+	assert.Equal(t, "token info", info.Info)
+	assert.Equal(t, domain.RoleAdmin, info.Role)
+	assert.Equal(t, "e-commerce-web", info.Audience)
+	assert.True(t, info.Verified)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), info.ExpiresAt, time.Minute)
+}
+
+func TestSignAllClaimsWithoutAllowlist(t *testing.T) {
+	token, err := newTokenService(t, domain.TokenConfig{}).Sign(context.Background(), domain.TokenInfo{Info: "token info", Role: "admin"}, 10)
+
+	assert.NoError(t, err)
+
+	claims := &Claims{}
+
+	_, err = jwt.ParseWithClaims(string(token), claims, func(t *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token info", claims.Info)
+	assert.Equal(t, "admin", claims.Role)
+}
+
+func TestSignOnlyAllowlistedClaims(t *testing.T) {
+	token, err := newTokenService(t, domain.TokenConfig{ClaimsAllowlist: []string{domain.TokenClaimInfo}}).Sign(context.Background(), domain.TokenInfo{Info: "token info", Role: "admin"}, 10)
+
+	assert.NoError(t, err)
+
+	var rawClaims jwt.MapClaims
+
+	_, err = jwt.ParseWithClaims(string(token), &rawClaims, func(t *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token info", rawClaims["Info"])
+	assert.NotContains(t, rawClaims, "Role")
+	assert.Contains(t, rawClaims, "exp")
+}
+
+func TestSignAudience(t *testing.T) {
+	token, err := newTokenService(t, domain.TokenConfig{}).Sign(context.Background(), domain.TokenInfo{Info: "token info", Audience: "e-commerce-web"}, 10)
+
+	assert.NoError(t, err)
+
+	claims := &Claims{}
+
+	_, err = jwt.ParseWithClaims(string(token), claims, func(t *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, claims.VerifyAudience("e-commerce-web", true))
+	assert.False(t, claims.VerifyAudience("e-commerce-mobile", true))
+}
+
+func TestIsValidConfiguredAudience(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{Audiences: []string{"e-commerce-web", "e-commerce-mobile"}})
+
+	token, err := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info", Audience: "e-commerce-mobile"}, 10)
+
+	assert.NoError(t, err)
+
+	isValid, err := ts.IsValid(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.True(t, bool(isValid))
+}
+
+func TestIsValidOtherAudience(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{Audiences: []string{"e-commerce-web"}})
+
+	for _, audience := range []string{"other-app", ""} {
+		token, err := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info", Audience: audience}, 10)
+
+		assert.NoError(t, err)
+
+		isValid, err := ts.IsValid(context.Background(), token)
+
+		assert.Error(t, err)
+		assert.False(t, bool(isValid))
+	}
+}
+
+func TestSignEncryptedRoundTrip(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{Encrypted: true, EncryptionKey: []byte("0123456789abcdef0123456789abcdef")})
+
+	token, err := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info", Role: domain.RoleCustomer}, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(string(token), "."), 5)
+	assert.NotContains(t, string(token), base64.RawURLEncoding.EncodeToString([]byte("token info")))
+
 	isValid, err := ts.IsValid(context.Background(), token)
 
 	assert.NoError(t, err)
 	assert.True(t, bool(isValid))
+
+	info, err := ts.GetInfo(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token info", info.Info)
+	assert.Equal(t, domain.RoleCustomer, info.Role)
+}
+
+func TestIsValidEncryptedWithWrongKey(t *testing.T) {
+	token, err := newTokenService(t, domain.TokenConfig{Encrypted: true, EncryptionKey: []byte("0123456789abcdef0123456789abcdef")}).Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
+
+	assert.NoError(t, err)
+
+	isValid, err := newTokenService(t, domain.TokenConfig{Encrypted: true, EncryptionKey: []byte("fedcba9876543210fedcba9876543210")}).IsValid(context.Background(), token)
+
+	assert.Error(t, err)
+	assert.False(t, bool(isValid))
+}
+
+func TestIsValidEncryptedExpired(t *testing.T) {
+	ts := newTokenService(t, domain.TokenConfig{Encrypted: true, EncryptionKey: []byte("0123456789abcdef0123456789abcdef")})
+
+	token, err := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info"}, -1)
+
+	assert.NoError(t, err)
+
+	isValid, err := ts.IsValid(context.Background(), token)
+
+	assert.Error(t, err)
+	assert.False(t, bool(isValid))
+}
+
+func TestNewTokenServiceInvalidKey(t *testing.T) {
+	for _, key := range []string{"", "short", "0123456789abcdef0123456789abcdef0"} {
+		ts, err := NewTokenService(domain.TokenConfig{Encrypted: true, EncryptionKey: []byte(key)})
+
+		assert.Error(t, err)
+		assert.Nil(t, ts)
+	}
+}
+
+func TestNewTokenServiceKeyUnusedWhenNotEncrypted(t *testing.T) {
+	ts, err := NewTokenService(domain.TokenConfig{EncryptionKey: []byte("short")})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, ts)
 }
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type twoFactorMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewTwoFactorMysqlRepository(conn *sql.DB) domain.TwoFactorRepository {
+	return &twoFactorMysqlRepository{Conn: conn}
+}
+
+func (r *twoFactorMysqlRepository) GetByLogin(ctx context.Context, login string) (*domain.TwoFactor, error) {
+	query := `SELECT login, secret, enabled FROM two_factor WHERE login = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, login)
+
+	var res domain.TwoFactor
+
+	if err := row.Scan(&res.Login, &res.Secret, &res.Enabled); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Store replaces the secret of the login, leaving two factor off until Enable.
+func (r *twoFactorMysqlRepository) Store(ctx context.Context, tf *domain.TwoFactor) error {
+	query := `INSERT INTO two_factor (login, secret, enabled) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled = VALUES(enabled);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, tf.Login, tf.Secret, tf.Enabled); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *twoFactorMysqlRepository) Enable(ctx context.Context, login string) error {
+	query := `UPDATE two_factor SET enabled = 1 WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to enable two factor with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *twoFactorMysqlRepository) StoreChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	query := `INSERT INTO two_factor_challenge (value, login, audience, expires_at) VALUES (?, ?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, c.Value, c.Login, c.Audience, c.ExpiresAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store two factor challenge with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *twoFactorMysqlRepository) GetChallengeByValue(ctx context.Context, value string) (*domain.TwoFactorChallenge, error) {
+	query := `SELECT value, login, audience, expires_at FROM two_factor_challenge WHERE value = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, value)
+
+	var res domain.TwoFactorChallenge
+
+	if err := row.Scan(&res.Value, &res.Login, &res.Audience, &res.ExpiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *twoFactorMysqlRepository) DeleteChallengeByValue(ctx context.Context, value string) error {
+	query := `DELETE FROM two_factor_challenge WHERE value = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, value); err != nil {
+		return err
+	}
+
+	return nil
+}
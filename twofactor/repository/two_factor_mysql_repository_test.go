@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByLoginNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT login, secret, enabled FROM two_factor WHERE login = ?;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnError(sql.ErrNoRows)
+
+	twoFactorMysqlRepository := NewTwoFactorMysqlRepository(db)
+
+	twoFactor, err := twoFactorMysqlRepository.GetByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Nil(t, twoFactor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO two_factor (login, secret, enabled) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled = VALUES(enabled);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "secret", false).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	twoFactorMysqlRepository := NewTwoFactorMysqlRepository(db)
+
+	err = twoFactorMysqlRepository.Store(context.Background(), &domain.TwoFactor{Login: "login", Secret: "secret"})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEnableNotSetUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE two_factor SET enabled = 1 WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	twoFactorMysqlRepository := NewTwoFactorMysqlRepository(db)
+
+	err = twoFactorMysqlRepository.Enable(context.Background(), "login")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetChallengeByValue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Date(2022, 5, 1, 10, 5, 0, 0, time.UTC)
+
+	query := regexp.QuoteMeta("SELECT value, login, audience, expires_at FROM two_factor_challenge WHERE value = ?;")
+
+	mock.ExpectQuery(query).WithArgs("value").WillReturnRows(sqlmock.NewRows([]string{"value", "login", "audience", "expires_at"}).AddRow("value", "login", "mobile", expiresAt))
+
+	twoFactorMysqlRepository := NewTwoFactorMysqlRepository(db)
+
+	challenge, err := twoFactorMysqlRepository.GetChallengeByValue(context.Background(), "value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.TwoFactorChallenge{Value: "value", Login: "login", Audience: "mobile", ExpiresAt: expiresAt}, challenge)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/go-sql-driver/mysql"
 )
 
 type userMysqlRepository struct {
@@ -32,3 +36,61 @@ func (r *userMysqlRepository) GetByEmail(ctx context.Context, email string) (*do
 
 	return &res, nil
 }
+
+func (r *userMysqlRepository) GetByPhone(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	query := `SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE phone_number = ? LIMIT 1;`
+
+	row := r.Conn.QueryRowContext(ctx, query, phoneNumber)
+
+	var res domain.User
+
+	if err := row.Scan(&res.ID, &res.UUID, &res.Email, &res.FirstName, &res.LastName, &res.PhoneNumber, &res.Address.City, &res.Address.State, &res.Address.Neighborhood, &res.Address.Street, &res.Address.Number, &res.Address.ZipCode); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *userMysqlRepository) Update(ctx context.Context, u *domain.User) error {
+	query := `UPDATE users SET first_name=?, last_name=?, phone_number=?, address_city=?, address_state=?, address_neighborhood=?, address_street=?, address_number=?, address_zipcode=? WHERE uuid=?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, u.FirstName, u.LastName, u.PhoneNumber, u.Address.City, u.Address.State, u.Address.Neighborhood, u.Address.Street, u.Address.Number, u.Address.ZipCode, u.UUID)
+
+	if isDuplicatePhone(err) {
+		return fmt.Errorf("user with phone number %s already exists: %w", u.PhoneNumber, domain.ErrPhoneTaken)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to update user with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+// isDuplicatePhone tells whether err is MySQL rejecting a row for the
+// user_phone_number_UN constraint.
+func isDuplicatePhone(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 && strings.Contains(mysqlErr.Message, "user_phone_number_UN")
+}
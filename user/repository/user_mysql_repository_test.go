@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,3 +94,123 @@ func TestGetByLogin(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetByPhoneNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"})
+
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE phone_number = ? LIMIT 1;")
+
+	mock.ExpectQuery(query).WithArgs("(11) 98888-8888").WillReturnRows(rows)
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	user, err := userMysqlRepository.GetByPhone(context.Background(), "(11) 98888-8888")
+
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByPhone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"}).AddRow(1, "uuid", "email", "first_name", "last_name", "(11) 98888-8888", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode")
+
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE phone_number = ? LIMIT 1;")
+
+	mock.ExpectQuery(query).WithArgs("(11) 98888-8888").WillReturnRows(rows)
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	user, err := userMysqlRepository.GetByPhone(context.Background(), "(11) 98888-8888")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", user.UUID)
+	assert.Equal(t, "(11) 98888-8888", user.PhoneNumber)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE users SET first_name=?, last_name=?, phone_number=?, address_city=?, address_state=?, address_neighborhood=?, address_street=?, address_number=?, address_zipcode=? WHERE uuid=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode", "uuid").WillReturnError(errors.New("error message"))
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	err = userMysqlRepository.Update(context.Background(), &domain.User{UUID: "uuid", FirstName: "first_name", LastName: "last_name", PhoneNumber: "phone_number", Address: domain.UserAddress{City: "address_city", State: "address_state", Neighborhood: "address_neighborhood", Street: "address_street", Number: "address_number", ZipCode: "address_zipcode"}})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateDuplicatePhone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE users SET first_name=?, last_name=?, phone_number=?, address_city=?, address_state=?, address_neighborhood=?, address_street=?, address_number=?, address_zipcode=? WHERE uuid=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode", "uuid").WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'phone_number' for key 'user_phone_number_UN'"})
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	err = userMysqlRepository.Update(context.Background(), &domain.User{UUID: "uuid", FirstName: "first_name", LastName: "last_name", PhoneNumber: "phone_number", Address: domain.UserAddress{City: "address_city", State: "address_state", Neighborhood: "address_neighborhood", Street: "address_street", Number: "address_number", ZipCode: "address_zipcode"}})
+
+	assert.ErrorIs(t, err, domain.ErrPhoneTaken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE users SET first_name=?, last_name=?, phone_number=?, address_city=?, address_state=?, address_neighborhood=?, address_street=?, address_number=?, address_zipcode=? WHERE uuid=?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode", "uuid").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	err = userMysqlRepository.Update(context.Background(), &domain.User{UUID: "uuid", FirstName: "first_name", LastName: "last_name", PhoneNumber: "phone_number", Address: domain.UserAddress{City: "address_city", State: "address_state", Neighborhood: "address_neighborhood", Street: "address_street", Number: "address_number", ZipCode: "address_zipcode"}})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
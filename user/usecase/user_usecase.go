@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type userUseCase struct {
+	userRepo      domain.UserRepository
+	consentRepo   domain.ConsentRepository
+	userValidator domain.UserValidator
+	conf          domain.UserConfig
+	now           func() time.Time
+}
+
+func NewUserUseCase(ur domain.UserRepository, cr domain.ConsentRepository, uv domain.UserValidator, conf domain.UserConfig) domain.UserUseCase {
+	return &userUseCase{userRepo: ur, consentRepo: cr, userValidator: uv, conf: conf, now: time.Now}
+}
+
+func (uu *userUseCase) GetProfile(ctx context.Context, login string) (*domain.User, error) {
+	return uu.userRepo.GetByEmail(ctx, login)
+}
+
+// RecordConsent appends the decision to the consent history, withdrawing is
+// recorded the same way with granted false.
+func (uu *userUseCase) RecordConsent(ctx context.Context, login string, purpose string, granted bool, source string) error {
+	if purpose != domain.ConsentPurposeMarketing {
+		return fmt.Errorf("consent purpose %s is not supported", purpose)
+	}
+
+	return uu.consentRepo.Store(ctx, &domain.Consent{Login: login, Purpose: purpose, Granted: granted, Source: source, RecordedAt: uu.now()})
+}
+
+// GetConsents returns the current consent for each purpose the login decided on.
+func (uu *userUseCase) GetConsents(ctx context.Context, login string) ([]domain.Consent, error) {
+	history, err := uu.consentRepo.GetByLogin(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var consents []domain.Consent
+
+	latest := make(map[string]int)
+
+	for _, c := range history {
+		if i, ok := latest[c.Purpose]; ok {
+			consents[i] = c
+			continue
+		}
+
+		latest[c.Purpose] = len(consents)
+		consents = append(consents, c)
+	}
+
+	return consents, nil
+}
+
+// UpdateProfile applies the non-empty fields of u over the stored profile. The
+// email is the login so it can not be changed here.
+func (uu *userUseCase) UpdateProfile(ctx context.Context, login string, u *domain.User) error {
+	current, err := uu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return fmt.Errorf("user with login %s not found", login)
+	}
+
+	if u.Email != "" && u.Email != current.Email {
+		return fmt.Errorf("email of login %s can not be changed on profile update", login)
+	}
+
+	updated := *current
+
+	if u.FirstName != "" {
+		updated.FirstName = u.FirstName
+	}
+
+	if u.LastName != "" {
+		updated.LastName = u.LastName
+	}
+
+	if u.PhoneNumber != "" {
+		updated.PhoneNumber = u.PhoneNumber
+	}
+
+	if u.Address.City != "" {
+		updated.Address.City = u.Address.City
+	}
+
+	if u.Address.State != "" {
+		updated.Address.State = u.Address.State
+	}
+
+	if u.Address.Neighborhood != "" {
+		updated.Address.Neighborhood = u.Address.Neighborhood
+	}
+
+	if u.Address.Street != "" {
+		updated.Address.Street = u.Address.Street
+	}
+
+	if u.Address.Number != "" {
+		updated.Address.Number = u.Address.Number
+	}
+
+	if u.Address.ZipCode != "" {
+		updated.Address.ZipCode = u.Address.ZipCode
+	}
+
+	if isValid, message := uu.userValidator.Validate(ctx, &updated); !isValid {
+		return fmt.Errorf("profile of login %s is not valid: %s", login, message)
+	}
+
+	if updated == *current {
+		return nil
+	}
+
+	if uu.conf.UniquePhoneNumbers && updated.PhoneNumber != current.PhoneNumber {
+		owner, err := uu.userRepo.GetByPhone(ctx, updated.PhoneNumber)
+
+		if err != nil {
+			return err
+		}
+
+		if owner != nil && owner.UUID != current.UUID {
+			return fmt.Errorf("user with phone number %s already exists: %w", updated.PhoneNumber, domain.ErrPhoneTaken)
+		}
+	}
+
+	return uu.userRepo.Update(ctx, &updated)
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProfileError(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(nil, errors.New("error message"))
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, nil, domain.UserConfig{})
+
+	_, err := userUseCase.GetProfile(context.Background(), "login@email.com")
+
+	assert.Error(t, err)
+}
+
+func TestGetProfileNotExists(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(nil, nil)
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, nil, domain.UserConfig{})
+
+	user, err := userUseCase.GetProfile(context.Background(), "login@email.com")
+
+	assert.Nil(t, user)
+	assert.NoError(t, err)
+}
+
+func TestGetProfile(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "first name", "last name", "phone number", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, nil, domain.UserConfig{})
+
+	user, err := userUseCase.GetProfile(context.Background(), "login@email.com")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.Equal(t, "uuid", user.UUID)
+	assert.Equal(t, "login@email.com", user.Email)
+	assert.Equal(t, "first name", user.FirstName)
+	assert.Equal(t, "last name", user.LastName)
+	assert.Equal(t, "phone number", user.PhoneNumber)
+	assert.Equal(t, "city", user.Address.City)
+	assert.Equal(t, "state", user.Address.State)
+	assert.Equal(t, "neighborhood", user.Address.Neighborhood)
+	assert.Equal(t, "street", user.Address.Street)
+	assert.Equal(t, "number", user.Address.Number)
+	assert.Equal(t, "zipcode", user.Address.ZipCode)
+}
+
+func TestRecordConsentUnsupportedPurpose(t *testing.T) {
+	mockConsentRepo := new(mocks.MockConsentRepository)
+
+	userUseCase := NewUserUseCase(nil, mockConsentRepo, nil, domain.UserConfig{})
+
+	err := userUseCase.RecordConsent(context.Background(), "login@email.com", "profiling", true, "settings")
+
+	assert.Error(t, err)
+	mockConsentRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestRecordConsent(t *testing.T) {
+	mockConsentRepo := new(mocks.MockConsentRepository)
+
+	recordedAt := time.Now()
+
+	mockConsentRepo.On("Store", mock.Anything, &domain.Consent{Login: "login@email.com", Purpose: domain.ConsentPurposeMarketing, Granted: true, Source: "signup", RecordedAt: recordedAt}).Return(nil)
+
+	uc := NewUserUseCase(nil, mockConsentRepo, nil, domain.UserConfig{}).(*userUseCase)
+	uc.now = func() time.Time { return recordedAt }
+
+	err := uc.RecordConsent(context.Background(), "login@email.com", domain.ConsentPurposeMarketing, true, "signup")
+
+	assert.NoError(t, err)
+	mockConsentRepo.AssertExpectations(t)
+}
+
+func TestRecordConsentWithdraw(t *testing.T) {
+	mockConsentRepo := new(mocks.MockConsentRepository)
+
+	mockConsentRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.Consent) bool {
+		return c.Purpose == domain.ConsentPurposeMarketing && !c.Granted && c.Source == "settings" && !c.RecordedAt.IsZero()
+	})).Return(nil)
+
+	userUseCase := NewUserUseCase(nil, mockConsentRepo, nil, domain.UserConfig{})
+
+	err := userUseCase.RecordConsent(context.Background(), "login@email.com", domain.ConsentPurposeMarketing, false, "settings")
+
+	assert.NoError(t, err)
+	mockConsentRepo.AssertExpectations(t)
+}
+
+func TestGetConsentsError(t *testing.T) {
+	mockConsentRepo := new(mocks.MockConsentRepository)
+
+	mockConsentRepo.On("GetByLogin", mock.Anything, "login@email.com").Return(nil, errors.New("error message"))
+
+	userUseCase := NewUserUseCase(nil, mockConsentRepo, nil, domain.UserConfig{})
+
+	_, err := userUseCase.GetConsents(context.Background(), "login@email.com")
+
+	assert.Error(t, err)
+}
+
+func TestGetConsentsLatestState(t *testing.T) {
+	mockConsentRepo := new(mocks.MockConsentRepository)
+
+	history := []domain.Consent{
+		{Login: "login@email.com", Purpose: domain.ConsentPurposeMarketing, Granted: true, Source: "signup", RecordedAt: time.Now().Add(-time.Hour)},
+		{Login: "login@email.com", Purpose: domain.ConsentPurposeMarketing, Granted: false, Source: "settings", RecordedAt: time.Now()},
+	}
+
+	mockConsentRepo.On("GetByLogin", mock.Anything, "login@email.com").Return(history, nil)
+
+	userUseCase := NewUserUseCase(nil, mockConsentRepo, nil, domain.UserConfig{})
+
+	consents, err := userUseCase.GetConsents(context.Background(), "login@email.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, consents, 1)
+	assert.False(t, consents[0].Granted)
+	assert.Equal(t, "settings", consents[0].Source)
+}
+
+func TestUpdateProfileNotExists(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(nil, nil)
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, nil, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{FirstName: "New"})
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfileEmailChange(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, nil, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{Email: "other@email.com"})
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfileInvalid(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	mockUserValidator.On("Validate", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.PhoneNumber == "11988888888"
+	})).Return(false, "user's phone number must obey the format (11) 11111-1111")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{PhoneNumber: "11988888888"})
+
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfileUpdateError(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	mockUserValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{FirstName: "New"})
+
+	assert.Error(t, err)
+}
+
+func TestUpdateProfileUnchanged(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	mockUserValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{FirstName: "First"})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfile(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	updated := domain.User{ID: 1, UUID: "uuid", Email: "login@email.com", FirstName: "New", LastName: "Last", PhoneNumber: "(11) 97777-7777", Address: domain.UserAddress{City: "city", State: "state", Neighborhood: "neighborhood", Street: "new street", Number: "number", ZipCode: "zipcode"}}
+
+	mockUserValidator.On("Validate", mock.Anything, &updated).Return(true, "")
+	mockUserRepo.On("Update", mock.Anything, &updated).Return(nil)
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{FirstName: "New", PhoneNumber: "(11) 97777-7777", Address: domain.UserAddress{Street: "new street"}})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUpdateProfilePhoneTaken(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockUserRepo.On("GetByPhone", mock.Anything, "(11) 97777-7777").Return(2, "other uuid", "other@email.com", "Other", "Last", "(11) 97777-7777", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+
+	mockUserValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{UniquePhoneNumbers: true})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{PhoneNumber: "(11) 97777-7777"})
+
+	assert.True(t, errors.Is(err, domain.ErrPhoneTaken))
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateProfileUniquePhoneFree(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockUserRepo.On("GetByPhone", mock.Anything, "(11) 97777-7777").Return(nil, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	mockUserValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{UniquePhoneNumbers: true})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{PhoneNumber: "(11) 97777-7777"})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUpdateProfileDuplicatePhoneAllowedWhenNotUnique(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserValidator := new(mocks.MockUserValidator)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "login@email.com").Return(1, "uuid", "login@email.com", "First", "Last", "(11) 98888-8888", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	mockUserValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+
+	userUseCase := NewUserUseCase(mockUserRepo, nil, mockUserValidator, domain.UserConfig{})
+
+	err := userUseCase.UpdateProfile(context.Background(), "login@email.com", &domain.User{PhoneNumber: "(11) 97777-7777"})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "GetByPhone", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type emailVerificationMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewEmailVerificationMysqlRepository(conn *sql.DB) domain.EmailVerificationRepository {
+	return &emailVerificationMysqlRepository{Conn: conn}
+}
+
+func (r *emailVerificationMysqlRepository) Store(ctx context.Context, ev *domain.EmailVerification) error {
+	query := `INSERT INTO email_verification (login, code, expires_at) VALUES (?, ?, ?);`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, ev.Login, ev.Code, ev.ExpiresAt)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to store email verification with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *emailVerificationMysqlRepository) GetByLogin(ctx context.Context, login string) (*domain.EmailVerification, error) {
+	query := `SELECT login, code, attempts, expires_at FROM email_verification WHERE login = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, login)
+
+	var res domain.EmailVerification
+
+	if err := row.Scan(&res.Login, &res.Code, &res.Attempts, &res.ExpiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *emailVerificationMysqlRepository) IncrementAttempts(ctx context.Context, login string) error {
+	query := `UPDATE email_verification SET attempts = attempts + 1 WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to count email verification attempt with total rows affected: %d", affect)
+	}
+
+	return nil
+}
+
+func (r *emailVerificationMysqlRepository) DeleteByLogin(ctx context.Context, login string) error {
+	query := `DELETE FROM email_verification WHERE login = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return fmt.Errorf("error trying to remove email verification with total rows affected: %d", affect)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO email_verification (login, code, expires_at) VALUES (?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "123456", expiresAt).WillReturnError(errors.New("error message"))
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	err = emailVerificationMysqlRepository.Store(context.Background(), &domain.EmailVerification{Login: "login", Code: "123456", ExpiresAt: expiresAt})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	query := regexp.QuoteMeta("INSERT INTO email_verification (login, code, expires_at) VALUES (?, ?, ?);")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "123456", expiresAt).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	err = emailVerificationMysqlRepository.Store(context.Background(), &domain.EmailVerification{Login: "login", Code: "123456", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByLoginNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"login", "code", "expires_at"})
+
+	query := regexp.QuoteMeta("SELECT login, code, attempts, expires_at FROM email_verification WHERE login = ?;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	verification, err := emailVerificationMysqlRepository.GetByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Nil(t, verification)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByLogin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"login", "code", "attempts", "expires_at"}).AddRow("login", "123456", 2, expiresAt)
+
+	query := regexp.QuoteMeta("SELECT login, code, attempts, expires_at FROM email_verification WHERE login = ?;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	verification, err := emailVerificationMysqlRepository.GetByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.EmailVerification{Login: "login", Code: "123456", Attempts: 2, ExpiresAt: expiresAt}, verification)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIncrementAttempts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("UPDATE email_verification SET attempts = attempts + 1 WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	err = emailVerificationMysqlRepository.IncrementAttempts(context.Background(), "login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteByLogin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM email_verification WHERE login = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	emailVerificationMysqlRepository := NewEmailVerificationMysqlRepository(db)
+
+	err = emailVerificationMysqlRepository.DeleteByLogin(context.Background(), "login")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
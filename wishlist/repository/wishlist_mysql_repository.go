@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type wishlistMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewWishlistMysqlRepository(conn *sql.DB) domain.WishlistRepository {
+	return &wishlistMysqlRepository{Conn: conn}
+}
+
+// Add ignores the insert when the product is already on the wishlist.
+func (r *wishlistMysqlRepository) Add(ctx context.Context, login string, productUUID string) error {
+	query := `INSERT IGNORE INTO wishlist_item (login, product_uuid, created_at) VALUES (?, ?, NOW());`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, login, productUUID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *wishlistMysqlRepository) Remove(ctx context.Context, login string, productUUID string) error {
+	query := `DELETE FROM wishlist_item WHERE login = ? AND product_uuid = ?;`
+
+	stmt, err := r.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, login, productUUID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *wishlistMysqlRepository) List(ctx context.Context, login string) ([]string, error) {
+	query := `SELECT product_uuid FROM wishlist_item WHERE login = ? ORDER BY created_at;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var res []string
+
+	for rows.Next() {
+		var productUUID string
+
+		if err := rows.Scan(&productUUID); err != nil {
+			return nil, err
+		}
+
+		res = append(res, productUUID)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdd(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT IGNORE INTO wishlist_item (login, product_uuid, created_at) VALUES (?, ?, NOW());")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "product").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	wishlistMysqlRepository := NewWishlistMysqlRepository(db)
+
+	err = wishlistMysqlRepository.Add(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("DELETE FROM wishlist_item WHERE login = ? AND product_uuid = ?;")
+
+	mock.ExpectPrepare(query)
+	mock.ExpectExec(query).WithArgs("login", "product").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	wishlistMysqlRepository := NewWishlistMysqlRepository(db)
+
+	err = wishlistMysqlRepository.Remove(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_uuid"}).
+		AddRow("product 1").
+		AddRow("product 2")
+
+	query := regexp.QuoteMeta("SELECT product_uuid FROM wishlist_item WHERE login = ? ORDER BY created_at;")
+
+	mock.ExpectQuery(query).WithArgs("login").WillReturnRows(rows)
+
+	wishlistMysqlRepository := NewWishlistMysqlRepository(db)
+
+	productUUIDs, err := wishlistMysqlRepository.List(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"product 1", "product 2"}, productUUIDs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
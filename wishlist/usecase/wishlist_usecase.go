@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type wishlistUseCase struct {
+	wishlistRepo domain.WishlistRepository
+	productRepo  domain.ProductRepository
+}
+
+func NewWishlistUseCase(wr domain.WishlistRepository, pr domain.ProductRepository) domain.WishlistUseCase {
+	return &wishlistUseCase{
+		wishlistRepo: wr,
+		productRepo:  pr,
+	}
+}
+
+// Add does nothing when the product is already on the wishlist.
+func (wu *wishlistUseCase) Add(ctx context.Context, login string, productUUID string) error {
+	productUUIDs, err := wu.wishlistRepo.List(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	for _, uuid := range productUUIDs {
+		if uuid == productUUID {
+			return nil
+		}
+	}
+
+	product, err := wu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil || !product.Active {
+		return fmt.Errorf("product %s not found", productUUID)
+	}
+
+	return wu.wishlistRepo.Add(ctx, login, productUUID)
+}
+
+// Remove does nothing when the product is not on the wishlist.
+func (wu *wishlistUseCase) Remove(ctx context.Context, login string, productUUID string) error {
+	return wu.wishlistRepo.Remove(ctx, login, productUUID)
+}
+
+// List skips the products that no longer exist or were deactivated since they were saved.
+func (wu *wishlistUseCase) List(ctx context.Context, login string) ([]domain.Product, error) {
+	productUUIDs, err := wu.wishlistRepo.List(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	products := []domain.Product{}
+
+	for _, uuid := range productUUIDs {
+		product, err := wu.productRepo.GetByUUID(ctx, uuid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if product == nil || !product.Active {
+			continue
+		}
+
+		products = append(products, *product)
+	}
+
+	return products, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddProductNotFound(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("List", mock.Anything, "login").Return([]string{}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(nil, nil)
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, mockProductRepo)
+
+	err := wishlistUseCase.Add(context.Background(), "login", "product")
+
+	assert.Error(t, err)
+	mockWishlistRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdd(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("List", mock.Anything, "login").Return([]string{}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(1, "product", 0, "", "name", "detail", false, "", "", 1990, "sku", 10, true, nil)
+	mockWishlistRepo.On("Add", mock.Anything, "login", "product").Return(nil)
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, mockProductRepo)
+
+	err := wishlistUseCase.Add(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestAddAlreadyOnWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("List", mock.Anything, "login").Return([]string{"other", "product"}, nil)
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, mockProductRepo)
+
+	err := wishlistUseCase.Add(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+	mockWishlistRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveNotOnWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("Remove", mock.Anything, "login", "product").Return(nil)
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, nil)
+
+	err := wishlistUseCase.Remove(context.Background(), "login", "product")
+
+	assert.NoError(t, err)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestListError(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("List", mock.Anything, "login").Return([]string{"product"}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product").Return(nil, errors.New("error message"))
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, mockProductRepo)
+
+	_, err := wishlistUseCase.List(context.Background(), "login")
+
+	assert.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("List", mock.Anything, "login").Return([]string{"product 1", "removed", "inactive", "product 2"}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product 1").Return(1, "product 1", 0, "", "name 1", "detail 1", false, "", "", 1990, "sku 1", 10, true, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "removed").Return(nil, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "inactive").Return(3, "inactive", 0, "", "name 3", "detail 3", false, "", "", 500, "sku 3", 10, false, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "product 2").Return(2, "product 2", 0, "", "name 2", "detail 2", false, "", "", 500, "sku 2", 0, true, nil)
+
+	wishlistUseCase := NewWishlistUseCase(mockWishlistRepo, mockProductRepo)
+
+	products, err := wishlistUseCase.List(context.Background(), "login")
+
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "product 1", products[0].UUID)
+	assert.Equal(t, "name 1", products[0].Name)
+	assert.Equal(t, int64(1990), products[0].Price)
+	assert.Equal(t, "product 2", products[1].UUID)
+	assert.Equal(t, "detail 2", products[1].Detail)
+}